@@ -0,0 +1,180 @@
+package dingo
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// FileStatus describes how a file of the generated package
+// differs from the file that should have been generated.
+type FileStatus string
+
+const (
+	// FileMissing means that the file should be generated but it does not exist.
+	FileMissing FileStatus = "missing"
+	// FileModified means that the content of the file is not the expected one.
+	FileModified FileStatus = "modified"
+	// FileUnexpected means that the file exists but it would not be generated.
+	FileUnexpected FileStatus = "unexpected"
+)
+
+// FileDiff is a file of the generated package that is not up to date.
+// For modified files, Line is the first line that differs (starting at 1).
+// Expected and Actual contain this line in the generated content
+// and in the file on disk.
+type FileDiff struct {
+	Path     string
+	Status   FileStatus
+	Line     int
+	Expected string
+	Actual   string
+}
+
+// ContainerDiff lists the files of the generated package
+// that are not up to date. The files are sorted by path.
+type ContainerDiff struct {
+	Files []*FileDiff
+}
+
+// IsEmpty returns true if the generated package is up to date.
+func (diff *ContainerDiff) IsEmpty() bool {
+	return len(diff.Files) == 0
+}
+
+// String returns a readable description of the differences.
+func (diff *ContainerDiff) String() string {
+	if diff.IsEmpty() {
+		return "the container is up to date"
+	}
+
+	lines := []string{"the container is out of date, it should be generated again:"}
+
+	for _, f := range diff.Files {
+		if f.Status != FileModified {
+			lines = append(lines, fmt.Sprintf("  - %s: %s", f.Path, f.Status))
+			continue
+		}
+		lines = append(lines,
+			fmt.Sprintf("  - %s: %s (first difference at line %d)", f.Path, f.Status, f.Line),
+			fmt.Sprintf("      expected: %q", f.Expected),
+			fmt.Sprintf("      actual:   %q", f.Actual),
+		)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// CheckContainer generates the container in memory
+// and compares it with the files of the pkgName package
+// in the outputDirectory. Nothing is written on disk.
+// The returned ContainerDiff is empty if the files are up to date.
+func CheckContainer(provider Provider, outputDirectory, pkgName string) (*ContainerDiff, error) {
+	scan, err := scanDefs(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := renderScan(scan, outputDirectory, pkgName)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(outputDirectory, pkgName)
+	diff := &ContainerDiff{Files: []*FileDiff{}}
+	expected := map[string]bool{}
+
+	for _, file := range files {
+		expected[file.name] = true
+
+		path := filepath.Join(dir, file.name)
+
+		content, err := ioutil.ReadFile(path)
+		if os.IsNotExist(err) {
+			diff.Files = append(diff.Files, &FileDiff{Path: path, Status: FileMissing})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not read %s: %v", path, err)
+		}
+
+		if fd := compareFile(path, file.content, content); fd != nil {
+			diff.Files = append(diff.Files, fd)
+		}
+	}
+
+	unexpected, err := unexpectedFiles(dir, expected)
+	if err != nil {
+		return nil, err
+	}
+
+	diff.Files = append(diff.Files, unexpected...)
+
+	sort.Slice(diff.Files, func(i, j int) bool {
+		return diff.Files[i].Path < diff.Files[j].Path
+	})
+
+	return diff, nil
+}
+
+// compareFile returns nil if the actual content is the expected one.
+// Otherwise it returns a FileDiff with the first line that differs.
+func compareFile(path string, expected, actual []byte) *FileDiff {
+	if bytes.Equal(expected, actual) {
+		return nil
+	}
+
+	expectedLines := strings.Split(string(expected), "\n")
+	actualLines := strings.Split(string(actual), "\n")
+
+	fd := &FileDiff{Path: path, Status: FileModified}
+
+	for i := 0; i < len(expectedLines) || i < len(actualLines); i++ {
+		var e, a string
+		if i < len(expectedLines) {
+			e = expectedLines[i]
+		}
+		if i < len(actualLines) {
+			a = actualLines[i]
+		}
+		if e != a || i >= len(expectedLines) || i >= len(actualLines) {
+			fd.Line = i + 1
+			fd.Expected = e
+			fd.Actual = a
+			break
+		}
+	}
+
+	return fd
+}
+
+// unexpectedFiles lists the files in dir that are not in the expected map.
+// The generation removes the whole directory, so files in sub-directories are also unexpected.
+func unexpectedFiles(dir string, expected map[string]bool) ([]*FileDiff, error) {
+	files := []*FileDiff{}
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if os.IsNotExist(err) && path == dir {
+			return filepath.SkipDir
+		}
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		if rel, _ := filepath.Rel(dir, path); !expected[rel] {
+			files = append(files, &FileDiff{Path: path, Status: FileUnexpected})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not list the files in %s: %v", dir, err)
+	}
+
+	return files, nil
+}
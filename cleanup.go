@@ -0,0 +1,81 @@
+package dingo
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// CleanupRegistry stores the cleanup functions returned by the Build functions
+// until the objects are closed. It is used by the generated code.
+//
+// The cleanup functions are stored by definition name and object,
+// so an unshared definition can have one cleanup function for each object.
+// The objects must be pointers to distinct values. The generator checks it
+// for the concrete types, and Add checks the values returned as an interface.
+type CleanupRegistry struct {
+	mu       sync.Mutex
+	cleanups map[cleanupKey]func() error
+}
+
+type cleanupKey struct {
+	name string
+	obj  interface{}
+}
+
+// Add stores the cleanup function of an object.
+// Nothing is stored if the cleanup function is nil.
+// If the object is not a pointer that can identify it,
+// the cleanup function is called immediately and an error is returned.
+func (r *CleanupRegistry) Add(name string, obj interface{}, cleanup func() error) error {
+	if cleanup == nil {
+		return nil
+	}
+
+	if err := checkCleanupObject(obj); err != nil {
+		if cleanupErr := cleanup(); cleanupErr != nil {
+			return fmt.Errorf("%v (cleanup error: %v)", err, cleanupErr)
+		}
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cleanups == nil {
+		r.cleanups = map[cleanupKey]func() error{}
+	}
+
+	r.cleanups[cleanupKey{name: name, obj: obj}] = cleanup
+
+	return nil
+}
+
+// checkCleanupObject returns an error if the object
+// can not be distinguished from the other objects of the definition.
+func checkCleanupObject(obj interface{}) error {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("could not store the cleanup function because the object is a %T and not a non-nil pointer", obj)
+	}
+	if v.Type().Elem().Size() == 0 {
+		return fmt.Errorf("could not store the cleanup function because the object is a %T that points to a zero-size type", obj)
+	}
+	return nil
+}
+
+// Run calls the cleanup function of an object and removes it from the registry.
+// It does nothing if there is no cleanup function for this object.
+func (r *CleanupRegistry) Run(name string, obj interface{}) error {
+	r.mu.Lock()
+	key := cleanupKey{name: name, obj: obj}
+	cleanup, ok := r.cleanups[key]
+	delete(r.cleanups, key)
+	r.mu.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	return cleanup()
+}
@@ -0,0 +1,159 @@
+package dingo
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/sarulabs/di/v2"
+)
+
+// ContextParam is the type of Context.
+type ContextParam struct{}
+
+// Context can be used as Params value for a context.Context parameter.
+// The parameter receives the context given to the generated methods
+// like SafeGetXCtx, or context.Background() if the object is retrieved
+// with the other methods. The first parameter of a Build function
+// is filled this way if it is a context.Context.
+var Context = ContextParam{}
+
+// ContextBuilder builds the objects of a definition that uses the context
+// given to the generated methods like SafeGetXCtx. It is used by the generated code.
+//
+// The generated code stores a ContextBuilder in the container for each of these definitions.
+// The context is given explicitly to the Build function, so each call only uses its own context.
+// The context is only used if the object is built during the call.
+// If the object already exists, it is returned as is.
+type ContextBuilder struct {
+	build    func(ctx context.Context) (interface{}, error)
+	unshared bool
+	closeObj func(obj interface{}) error
+
+	mu      sync.Mutex
+	obj     interface{}
+	built   bool
+	objects []interface{}
+}
+
+// NewContextBuilder creates a ContextBuilder.
+// The build function retrieves the dependencies from ctn,
+// the container of the definition scope.
+// The closeObj function is used to close the objects of an unshared definition
+// that are built with a context. It can be nil.
+func NewContextBuilder(
+	ctn di.Container,
+	build func(ctn di.Container, ctx context.Context) (interface{}, error),
+	unshared bool,
+	closeObj func(obj interface{}) error,
+) *ContextBuilder {
+	return &ContextBuilder{
+		build: func(ctx context.Context) (interface{}, error) {
+			return build(ctn, ctx)
+		},
+		unshared: unshared,
+		closeObj: closeObj,
+	}
+}
+
+// GetContextBuilder retrieves the ContextBuilder stored in the container under the given name.
+func GetContextBuilder(ctn di.Container, name string) (*ContextBuilder, error) {
+	i, err := ctn.SafeGet(name)
+	if err != nil {
+		return nil, err
+	}
+
+	b, ok := i.(*ContextBuilder)
+	if !ok {
+		return nil, errors.New("could not cast " + name + " to *dingo.ContextBuilder")
+	}
+
+	return b, nil
+}
+
+// Build builds an object with context.Background().
+// It is used by the di.Def of the definition,
+// so the container handles the object as usual.
+func (b *ContextBuilder) Build() (interface{}, error) {
+	if b.unshared {
+		return b.build(context.Background())
+	}
+	return b.buildShared(context.Background())
+}
+
+// SafeGet retrieves the object of the definition named name from the container.
+// If the object is built during the call, ctx is given to the Build function.
+//
+// The objects of an unshared definition are not stored in the container.
+// They are closed when the ContextBuilder is closed.
+func (b *ContextBuilder) SafeGet(ctn di.Container, name string, ctx context.Context) (interface{}, error) {
+	if b.unshared {
+		obj, err := b.build(ctx)
+		if err != nil || b.closeObj == nil {
+			return obj, err
+		}
+
+		b.mu.Lock()
+		b.objects = append(b.objects, obj)
+		b.mu.Unlock()
+
+		return obj, nil
+	}
+
+	if _, err := b.buildShared(ctx); err != nil {
+		return nil, err
+	}
+
+	// The object is already built, so the di.Def returns it.
+	return ctn.SafeGet(name)
+}
+
+// buildShared builds the object of a shared definition on the first successful call.
+// The next calls return the same object.
+func (b *ContextBuilder) buildShared(ctx context.Context) (interface{}, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.built {
+		return b.obj, nil
+	}
+
+	obj, err := b.build(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	b.obj = obj
+	b.built = true
+
+	return obj, nil
+}
+
+// Close closes the objects of an unshared definition that were built by SafeGet.
+// It returns the last error, but all the objects are closed.
+func (b *ContextBuilder) Close() error {
+	b.mu.Lock()
+	objects := b.objects
+	b.objects = nil
+	b.mu.Unlock()
+
+	var err error
+
+	for i := len(objects) - 1; i >= 0; i-- {
+		if closeErr := b.closeObj(objects[i]); closeErr != nil {
+			err = closeErr
+		}
+	}
+
+	return err
+}
+
+// CloseContextBuilder closes a ContextBuilder.
+// It can be used as the Close function of the di.Def that stores the ContextBuilder.
+func CloseContextBuilder(obj interface{}) error {
+	b, ok := obj.(*ContextBuilder)
+	if !ok {
+		return errors.New("could not cast object to *dingo.ContextBuilder")
+	}
+	return b.Close()
+}
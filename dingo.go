@@ -1,6 +1,9 @@
 package dingo
 
-import "strconv"
+import (
+	"runtime"
+	"strconv"
+)
 
 // Def is the structure containing a service definition.
 type Def struct {
@@ -29,6 +32,58 @@ type Def struct {
 	// Description is a text that describes the service.
 	// If provided, the description is used in the comments of the generated code.
 	Description string
+	// Order is used to sort the definitions when several of them
+	// are injected in the same slice parameter (see All).
+	// Definitions with a lower Order come first.
+	// Definitions with the same Order are sorted by name.
+	Order int
+	// AutoFillByName is used when several definitions could autofill a field of the Build structure.
+	// The definition whose formatted name (see FormatDefName) matches the field name
+	// (case-insensitive) is used. It is not available for Build functions
+	// because the names of the function parameters are not known.
+	AutoFillByName bool
+	// Primary is used to choose the definition when several of them
+	// could autofill a parameter. At most one primary definition can match a parameter.
+	// A primary definition can not use NotForAutoFill.
+	Primary bool
+	// As restricts the interfaces the definition can be autofilled as.
+	// Each element must be a pointer to an interface implemented by the object,
+	// for example (*Logger)(nil). If As is empty, the definition can fill
+	// any interface parameter it implements.
+	As []interface{}
+	// Tags are passed to the generated di.Def.
+	// They can be used to inject all the tagged services with Tagged.
+	Tags []Tag
+	// Decorates is the name of the definition wrapped by this definition.
+	// The decorator receives the decorated object in the parameter filled with Decorated.
+	// If there is no such parameter, the first parameter whose type is
+	// the type of the decorated definition is used.
+	// Several decorators of the same definition are applied by Order and name,
+	// the first one receiving the original object.
+	// The other definitions and the container methods of the decorated definition
+	// get the object of the last decorator. Decorators are not used for autofill.
+	Decorates string
+	// Aliases are other names that can be used to retrieve the object.
+	// They can be used in SafeGet and Service, and the container has
+	// deprecated methods for each of them (e.g. GetOldName).
+	// It can be used to keep the old name of a renamed definition for a while.
+	Aliases []string
+	// Location is the place (file:line) where the definition is declared.
+	// It is set by Declare. If it is empty, BaseProvider.AddDef sets it
+	// to the place where the definition is added in the Provider.
+	// The definitions added with a slice have no Location unless they use Declare.
+	// It is used in the error messages and in the comments of the generated code.
+	Location string
+}
+
+// Declare returns the given definition with its Location set
+// to the place where Declare is called.
+// e.g.: var MyDefs = []dingo.Def{dingo.Declare(dingo.Def{Name: "my-service", ...})}
+func Declare(def Def) Def {
+	if _, file, line, ok := runtime.Caller(1); ok {
+		def.Location = file + ":" + strconv.Itoa(line)
+	}
+	return def
 }
 
 // Params are used to assist the service constructor.
@@ -39,7 +94,7 @@ type Def struct {
 // But the key of the map should be the index
 // of the function parameters (e.g.: "0", "1", ...).
 //
-// key=fieldName¦paramIndex value=any¦dingo.Service|dingo.AutoFill
+// key=fieldName¦paramIndex value=any¦dingo.Service|dingo.OptionalService|dingo.LazyService|dingo.Optional()|[]dingo.Service|dingo.AutoFill|dingo.All()|dingo.Tagged|dingo.Context|dingo.Arg()|dingo.Decorated
 type Params map[string]interface{}
 
 // NewFuncParams creates a Params instance where the key of the map, is the index of the given parameter.
@@ -64,6 +119,83 @@ type Service string
 // Setting the entry to AutoFill(false) will let the field empty in the structure.
 type AutoFill bool
 
+// OptionalService can be used as Params value.
+// It is similar to Service, but the parameter keeps its zero value
+// if the service is not defined when the container is generated,
+// or if it has been removed from the builder at runtime.
+type OptionalService string
+
+// LazyService can be used as Params value for a parameter
+// whose type is func() (T, error) or dingo.Lazy[T].
+// The parameter is a function that retrieves the service
+// from the container the first time it is called, instead of when the object is built.
+// Parameters with these types that are not in the Params map
+// are filled this way if there is no definition with the function type.
+type LazyService string
+
+// OptionalAutoFill is the type of the value returned by Optional.
+type OptionalAutoFill struct{}
+
+// Optional can be used as Params value.
+// The parameter is automatically filled like with AutoFill(true),
+// but it keeps its zero value if there is no matching definition,
+// even for Build functions.
+func Optional() OptionalAutoFill {
+	return OptionalAutoFill{}
+}
+
+// AllServices is the type of the value returned by All.
+type AllServices struct{}
+
+// All can be used as Params value for a slice parameter.
+// The slice will contain all the services whose type is (or implements)
+// the type of the slice elements, sorted by Def.Order and name.
+// Definitions with NotForAutoFill are not used.
+// Slice parameters that are not in the Params map are filled this way
+// if there is no definition of the slice type but at least one definition of the element type.
+func All() AllServices {
+	return AllServices{}
+}
+
+// Tag can be added to a definition to group it with other definitions.
+// Args are optional attributes. The "priority" argument must be an integer.
+// It is used to sort the tagged services (highest priority first).
+type Tag struct {
+	Name string
+	Args map[string]string
+}
+
+// TaggedServices is the type of the value returned by Tagged.
+type TaggedServices string
+
+// Tagged can be used as Params value for a slice parameter.
+// The slice will contain all the services tagged with the given name,
+// sorted by priority, Def.Order and name.
+func Tagged(name string) TaggedServices {
+	return TaggedServices(name)
+}
+
+// ArgParam is the type of the value returned by Arg.
+type ArgParam string
+
+// Arg can be used as Params value for a parameter
+// that is only known when the object is created.
+// The definition becomes a factory: the container has a NewX method
+// that takes the arguments in the order of the parameters
+// and creates a new object at each call. The object is not stored in the container.
+// The name is the name of the argument in the NewX method.
+// A factory can not be used to fill the parameters of other definitions.
+func Arg(name string) ArgParam {
+	return ArgParam(name)
+}
+
+// DecoratedParam is the type of Decorated.
+type DecoratedParam struct{}
+
+// Decorated can be used as Params value in a decorator (see Def.Decorates).
+// The parameter receives the decorated object.
+var Decorated = DecoratedParam{}
+
 // ContainerKey is a type that can be used as key in a context.Context.
 // For example it can be use if you want to store
 // a container in the Context of an http.Request.
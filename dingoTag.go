@@ -0,0 +1,57 @@
+package dingo
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// dingoTag contains the information of the dingo tag
+// of a field in a Build structure. The options are separated by commas:
+//
+//	`dingo:"name"`           the name of the definition used to autofill the field,
+//	                         it must be one of the definitions that have its type
+//	`dingo:"service=logger"` same as dingo.Service("logger") in the Params
+//	`dingo:"-"`              the field is not filled
+//	`dingo:"autofill=false"` same as dingo.AutoFill(false) in the Params
+//	`dingo:"optional"`       the field keeps its zero value if the service does not exist
+//
+// The Params of the definition have priority over the tag.
+type dingoTag struct {
+	name       string
+	service    string
+	skip       bool
+	noAutoFill bool
+	optional   bool
+}
+
+func parseDingoTag(tag reflect.StructTag) (dingoTag, error) {
+	t := dingoTag{}
+
+	for _, part := range strings.Split(tag.Get("dingo"), ",") {
+		part = strings.TrimSpace(part)
+
+		switch {
+		case part == "":
+		case part == "-":
+			t.skip = true
+		case part == "optional":
+			t.optional = true
+		case part == "autofill=false":
+			t.noAutoFill = true
+		case part == "autofill=true":
+			t.noAutoFill = false
+		case strings.HasPrefix(part, "service="):
+			t.service = strings.TrimPrefix(part, "service=")
+			if t.service == "" {
+				return t, errors.New("the dingo tag option service should not be empty")
+			}
+		case strings.Contains(part, "="):
+			return t, errors.New("the dingo tag option " + part + " is not supported")
+		default:
+			t.name = part
+		}
+	}
+
+	return t, nil
+}
@@ -0,0 +1,99 @@
+package dingo
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrorCode identifies the kind of problem found in a definition.
+type ErrorCode string
+
+// Codes of the errors returned when the definitions are scanned.
+const (
+	CodeInvalidName      ErrorCode = "invalid_name"
+	CodeInvalidScope     ErrorCode = "invalid_scope"
+	CodeInvalidBuild     ErrorCode = "invalid_build"
+	CodeInvalidClose     ErrorCode = "invalid_close"
+	CodeInvalidAs        ErrorCode = "invalid_as"
+	CodeInvalidTag       ErrorCode = "invalid_tag"
+	CodeInvalidDecorates ErrorCode = "invalid_decorates"
+	CodeUnknownParam     ErrorCode = "unknown_param"
+	CodeInvalidParam     ErrorCode = "invalid_param"
+	CodeUnknownService   ErrorCode = "unknown_service"
+	CodeAutoFill         ErrorCode = "autofill"
+	CodeInvalidPrimary   ErrorCode = "invalid_primary"
+	CodeScopeMismatch    ErrorCode = "scope_mismatch"
+	CodeCycle            ErrorCode = "cycle"
+)
+
+// DefError is a problem found while scanning a definition.
+// ParamName is empty if the problem is not related to a param.
+// Location is the place where the definition is declared (see Def.Location).
+type DefError struct {
+	Code      ErrorCode
+	DefName   string
+	ParamName string
+	Location  string
+	Err       error
+}
+
+// Error returns the error message, prefixed by the definition name and location, and the param name.
+func (e *DefError) Error() string {
+	prefix := "definition " + e.DefName
+	if e.Location != "" {
+		prefix += " (" + e.Location + ")"
+	}
+	if e.ParamName != "" {
+		prefix += ", param " + e.ParamName
+	}
+	return prefix + " [" + string(e.Code) + "]: " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *DefError) Unwrap() error {
+	return e.Err
+}
+
+// DefErrors contains all the problems found while scanning the definitions.
+type DefErrors []*DefError
+
+// Error returns the messages of all the errors, one per line.
+func (errs DefErrors) Error() string {
+	lines := make([]string, 0, len(errs)+1)
+
+	if len(errs) == 1 {
+		lines = append(lines, "1 error found in the definitions:")
+	} else {
+		lines = append(lines, strconv.Itoa(len(errs))+" errors found in the definitions:")
+	}
+
+	for _, err := range errs {
+		lines = append(lines, "  - "+err.Error())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// newDefError creates a DefError. The definition and param names
+// can be left empty if they are set later by toDefError.
+func newDefError(code ErrorCode, msg string) *DefError {
+	return &DefError{Code: code, Err: errors.New(msg)}
+}
+
+// toDefError converts an error into a DefError.
+// If the error is already a DefError, only its empty fields are updated.
+// Otherwise the given code is used.
+func toDefError(err error, code ErrorCode, defName, paramName string) *DefError {
+	defErr, ok := err.(*DefError)
+	if !ok {
+		defErr = &DefError{Code: code, Err: err}
+	}
+	if defErr.DefName == "" {
+		defErr.DefName = defName
+	}
+	if defErr.ParamName == "" {
+		defErr.ParamName = paramName
+	}
+	return defErr
+}
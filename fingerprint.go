@@ -0,0 +1,61 @@
+package dingo
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// Fingerprints scans the definitions of the Provider
+// and returns the fingerprint of each definition.
+// The key of the map is the definition name.
+func Fingerprints(provider Provider) (map[string]string, error) {
+	scan, err := scanDefs(provider)
+	if err != nil {
+		return nil, err
+	}
+	return scan.Fingerprints(), nil
+}
+
+// CheckFingerprints compares the fingerprints of the Provider definitions
+// with the fingerprints saved in the generated container.
+// It returns an error listing the definitions that differ
+// if the container needs to be generated again.
+// The Provider must already be loaded, like the one used by NewBuilder.
+// Its Load method is not called again.
+func CheckFingerprints(provider Provider, fingerprints map[string]string) error {
+	scanner := &Scanner{Provider: provider}
+
+	scan, err := scanner.ScanLoaded()
+	if err != nil {
+		return errors.New("the container is out of date, regenerate it: " + err.Error())
+	}
+
+	current := scan.Fingerprints()
+
+	diffs := []string{}
+
+	for name, fingerprint := range current {
+		expected, ok := fingerprints[name]
+		if !ok {
+			diffs = append(diffs, name+" (added)")
+		} else if expected != fingerprint {
+			diffs = append(diffs, name+" (modified)")
+		}
+	}
+
+	for name := range fingerprints {
+		if _, ok := current[name]; !ok {
+			diffs = append(diffs, name+" (removed)")
+		}
+	}
+
+	if len(diffs) == 0 {
+		return nil
+	}
+
+	sort.Strings(diffs)
+
+	return errors.New("the container is out of date, regenerate it (definitions that differ: " +
+		strings.Join(diffs, ", ") + ")")
+}
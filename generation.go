@@ -2,7 +2,9 @@ package dingo
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 
 	"github.com/sarulabs/dingo/v4/templates"
 )
@@ -31,44 +33,88 @@ func scanDefs(provider Provider) (*Scan, error) {
 
 	scan, err := scanner.Scan()
 	if err != nil {
-		return nil, fmt.Errorf("could not scan definitions: %v", err)
+		return nil, fmt.Errorf("could not scan definitions: %w", err)
 	}
 
 	return scan, nil
 }
 
-func writeScan(scan *Scan, outputDirectory, pkgName string) error {
-	err := os.RemoveAll(outputDirectory + "/" + pkgName)
-	if err != nil {
-		return fmt.Errorf("could not remove destination directory: %v", err)
-	}
+// generatedFile is a file of the generated package.
+type generatedFile struct {
+	name    string
+	content []byte
+}
+
+// renderScan generates the content of the files of the container package.
+// The files are sorted by name.
+func renderScan(scan *Scan, outputDirectory, pkgName string) ([]*generatedFile, error) {
+	dir := filepath.Join(outputDirectory, pkgName)
 
-	err = templates.WriteTemplate(
-		outputDirectory+"/"+pkgName+"/defs.go",
+	defs, err := templates.RenderTemplate(
+		filepath.Join(dir, "defs.go"),
 		templates.DefsTemplate,
 		map[string]interface{}{
-			"PkgName": pkgName,
-			"Imports": scan.TypeManager.Imports(),
-			"Defs":    scan.Defs,
+			"PkgName":           pkgName,
+			"Imports":           scan.TypeManager.Imports(),
+			"Defs":              scan.Defs,
+			"UsesGenerics":      scan.TypeManager.UsesGenerics(),
+			"UsesCleanups":      scan.UsesCleanups(),
+			"UsesContexts":      scan.UsesContexts(),
+			"ContextTypeString": scan.ContextTypeString,
 		},
 	)
 	if err != nil {
-		return fmt.Errorf("could not generate definition file: %v", err)
+		return nil, fmt.Errorf("could not generate definition file: %v", err)
 	}
 
-	err = templates.WriteTemplate(
-		outputDirectory+"/"+pkgName+"/container.go",
+	container, err := templates.RenderTemplate(
+		filepath.Join(dir, "container.go"),
 		templates.ContainerTemplate,
 		map[string]interface{}{
-			"PkgName":         pkgName,
-			"Imports":         scan.ImportsWithoutParams,
-			"Defs":            scan.Defs,
-			"ProviderPackage": scan.ProviderPackage,
-			"ProviderName":    scan.ProviderName,
+			"PkgName":           pkgName,
+			"Imports":           scan.ImportsWithoutParams,
+			"Defs":              scan.Defs,
+			"ProviderPackage":   scan.ProviderPackage,
+			"ProviderName":      scan.ProviderName,
+			"Scopes":            scan.Scopes,
+			"Tags":              scan.Tags,
+			"ContextTypeString": scan.ContextTypeString,
+			"Fingerprints":      scan.Fingerprints(),
+			"UsesGenerics":      scan.TypeManager.UsesGenerics(),
 		},
 	)
 	if err != nil {
-		return fmt.Errorf("could not generate container file: %v", err)
+		return nil, fmt.Errorf("could not generate container file: %v", err)
+	}
+
+	files := []*generatedFile{
+		{name: "container.go", content: container},
+		{name: "defs.go", content: defs},
+	}
+
+	return files, nil
+}
+
+func writeScan(scan *Scan, outputDirectory, pkgName string) error {
+	files, err := renderScan(scan, outputDirectory, pkgName)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(outputDirectory, pkgName)
+
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("could not remove destination directory: %v", err)
+	}
+
+	if err := os.MkdirAll(dir, 0775); err != nil {
+		return fmt.Errorf("could not create destination directory: %v", err)
+	}
+
+	for _, file := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, file.name), file.content, 0664); err != nil {
+			return fmt.Errorf("could not write %s: %v", file.name, err)
+		}
 	}
 
 	return nil
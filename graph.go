@@ -0,0 +1,189 @@
+package dingo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// GraphFormat is the output format of ExportGraph.
+type GraphFormat string
+
+const (
+	// GraphDOT is the Graphviz DOT format.
+	GraphDOT GraphFormat = "dot"
+	// GraphMermaid is the Mermaid flowchart format.
+	GraphMermaid GraphFormat = "mermaid"
+	// GraphJSON is the Graph structure encoded in JSON.
+	GraphJSON GraphFormat = "json"
+)
+
+// Graph is the dependency graph of the definitions.
+type Graph struct {
+	Nodes []*GraphNode `json:"nodes"`
+	Edges []*GraphEdge `json:"edges"`
+}
+
+// GraphNode represents a definition in the Graph.
+type GraphNode struct {
+	Name     string `json:"name"`
+	Scope    string `json:"scope"`
+	Type     string `json:"type"`
+	Build    string `json:"build"`
+	Unshared bool   `json:"unshared"`
+	Close    bool   `json:"close"`
+}
+
+// GraphEdge represents a dependency between two definitions.
+// From is the name of the definition that uses the service named To.
+// AutoFilled is false if the dependency was explicitly declared with dingo.Service.
+type GraphEdge struct {
+	From       string `json:"from"`
+	To         string `json:"to"`
+	Param      string `json:"param"`
+	AutoFilled bool   `json:"autofilled"`
+}
+
+// ExportGraph scans the definitions of the Provider
+// and writes their dependency graph in the given format.
+func ExportGraph(provider Provider, format GraphFormat, w io.Writer) error {
+	scan, err := scanDefs(provider)
+	if err != nil {
+		return err
+	}
+
+	g := NewGraph(scan)
+
+	switch format {
+	case GraphDOT:
+		return g.WriteDOT(w)
+	case GraphMermaid:
+		return g.WriteMermaid(w)
+	case GraphJSON:
+		return g.WriteJSON(w)
+	default:
+		return errors.New("unknown graph format " + string(format) + " (allowed formats: dot, mermaid, json)")
+	}
+}
+
+// NewGraph creates the dependency graph of the scanned definitions.
+// Nodes are sorted by name, and edges by definition and param names.
+func NewGraph(scan *Scan) *Graph {
+	g := &Graph{
+		Nodes: make([]*GraphNode, 0, len(scan.Defs)),
+		Edges: []*GraphEdge{},
+	}
+
+	for _, def := range scan.Defs {
+		g.Nodes = append(g.Nodes, &GraphNode{
+			Name:     def.Name,
+			Scope:    scan.Scopes[scan.ScopeLevel(def.Scope)],
+			Type:     def.ObjectType.String(),
+			Build:    def.BuildKind(),
+			Unshared: def.Unshared,
+			Close:    def.Def.Close != nil || def.BuildCleanupTypeString != "",
+		})
+
+		for _, param := range def.sortedParams() {
+			for _, dep := range param.Dependencies() {
+				g.Edges = append(g.Edges, &GraphEdge{
+					From:       def.Name,
+					To:         dep,
+					Param:      param.Name,
+					AutoFilled: param.AutoFilled,
+				})
+			}
+		}
+	}
+
+	return g
+}
+
+// WriteJSON writes the Graph in JSON.
+func (g *Graph) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(g)
+}
+
+// WriteDOT writes the Graph in the Graphviz DOT format.
+// Autofilled dependencies are drawn with dashed lines.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	buf := &strings.Builder{}
+
+	buf.WriteString("digraph dingo {\n")
+	buf.WriteString("\tnode [shape=box];\n")
+
+	for _, node := range g.Nodes {
+		fmt.Fprintf(buf, "\t%s [label=%s];\n", strconv.Quote(node.Name), strconv.Quote(node.label("\n")))
+	}
+
+	for _, edge := range g.Edges {
+		style := "solid"
+		if edge.AutoFilled {
+			style = "dashed"
+		}
+		fmt.Fprintf(buf, "\t%s -> %s [label=%s, style=%s];\n",
+			strconv.Quote(edge.From), strconv.Quote(edge.To), strconv.Quote(edge.Param), style)
+	}
+
+	buf.WriteString("}\n")
+
+	_, err := io.WriteString(w, buf.String())
+	return err
+}
+
+// WriteMermaid writes the Graph as a Mermaid flowchart.
+// Autofilled dependencies are drawn with dotted lines.
+func (g *Graph) WriteMermaid(w io.Writer) error {
+	buf := &strings.Builder{}
+	ids := make(map[string]string, len(g.Nodes))
+
+	buf.WriteString("flowchart LR\n")
+
+	for i, node := range g.Nodes {
+		ids[node.Name] = "n" + strconv.Itoa(i)
+		fmt.Fprintf(buf, "\t%s[\"%s\"]\n", ids[node.Name], mermaidEscape(node.label("<br/>")))
+	}
+
+	for _, edge := range g.Edges {
+		arrow := "-->"
+		if edge.AutoFilled {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(buf, "\t%s %s|\"%s\"| %s\n", ids[edge.From], arrow, mermaidEscape(edge.Param), ids[edge.To])
+	}
+
+	_, err := io.WriteString(w, buf.String())
+	return err
+}
+
+// label returns the text displayed in the node.
+// The lines are separated by the given separator.
+func (node *GraphNode) label(sep string) string {
+	lines := []string{
+		node.Name,
+		node.Type,
+		"scope: " + node.Scope + ", build: " + node.Build,
+	}
+
+	flags := []string{}
+	if node.Unshared {
+		flags = append(flags, "unshared")
+	}
+	if node.Close {
+		flags = append(flags, "close")
+	}
+	if len(flags) > 0 {
+		lines = append(lines, strings.Join(flags, ", "))
+	}
+
+	return strings.Join(lines, sep)
+}
+
+func mermaidEscape(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "\n", " ").Replace(s)
+}
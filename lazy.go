@@ -0,0 +1,39 @@
+package dingo
+
+import "sync"
+
+// LazyResolver retrieves a service the first time it is called.
+// It is used in the generated code to fill the lazy parameters.
+// The result is kept if the service could be retrieved,
+// otherwise the next call retries to retrieve it.
+type LazyResolver struct {
+	mu       sync.Mutex
+	get      func() (interface{}, error)
+	obj      interface{}
+	resolved bool
+}
+
+// NewLazyResolver creates a LazyResolver that uses the get function to retrieve the service.
+func NewLazyResolver(get func() (interface{}, error)) *LazyResolver {
+	return &LazyResolver{get: get}
+}
+
+// Resolve returns the service, retrieving it on the first call.
+func (r *LazyResolver) Resolve() (interface{}, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.resolved {
+		return r.obj, nil
+	}
+
+	obj, err := r.get()
+	if err != nil {
+		return nil, err
+	}
+
+	r.obj = obj
+	r.resolved = true
+
+	return obj, nil
+}
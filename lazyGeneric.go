@@ -0,0 +1,10 @@
+//go:build go1.18
+// +build go1.18
+
+package dingo
+
+// Lazy is a function that returns a service.
+// It can be used as parameter type instead of func() (T, error).
+// The parameter is filled with a function that retrieves the service
+// from the container the first time it is called (see LazyService).
+type Lazy[T any] func() (T, error)
@@ -0,0 +1,53 @@
+package dingo
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// callerLocation returns the location (file:line) of the first function
+// in the call stack that is not a method of BaseProvider.
+func callerLocation() string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	for {
+		frame, more := frames.Next()
+
+		internal := strings.Contains(frame.Function, ".(*BaseProvider).") ||
+			strings.HasPrefix(frame.File, "<autogenerated>")
+
+		if !internal && frame.File != "" {
+			return frame.File + ":" + strconv.Itoa(frame.Line)
+		}
+
+		if !more {
+			return ""
+		}
+	}
+}
+
+// moduleRelativeLocation returns the location relative to the root of the go module
+// containing the file. Unlike the absolute path, it does not depend on the machine
+// where the code is generated. If the module can not be found, the location is returned as is.
+func moduleRelativeLocation(location string) string {
+	if !filepath.IsAbs(location) {
+		return location
+	}
+
+	for dir := filepath.Dir(location); dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			rel, err := filepath.Rel(dir, location)
+			if err != nil {
+				return location
+			}
+			return filepath.ToSlash(rel)
+		}
+	}
+
+	return location
+}
@@ -1,9 +1,13 @@
 package dingo
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"go/token"
+	"go/types"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -11,9 +15,11 @@ import (
 // ParamScanner helps the Scanner.
 // It scans information about params.
 type ParamScanner struct {
-	scan       *Scan
-	defsByName map[string]*ScannedDef
-	defsByType map[string][]*ScannedDef
+	scan         *Scan
+	defsByName   map[string]*ScannedDef
+	aliases      map[string]*ScannedDef
+	defsByType   map[string][]*ScannedDef
+	autofillDefs []*ScannedDef
 }
 
 // Scan updates the given Scan with data about params.
@@ -23,57 +29,375 @@ func (s *ParamScanner) Scan(scan *Scan) error {
 	// set defsByName and defsByType
 	// defsByType only contains definitions available for autofill
 	s.defsByName = map[string]*ScannedDef{}
+	s.aliases = map[string]*ScannedDef{}
 	s.defsByType = map[string][]*ScannedDef{}
+	s.autofillDefs = []*ScannedDef{}
 
 	for _, def := range scan.Defs {
 		s.defsByName[def.Name] = def
-		if !def.Def.NotForAutoFill {
+		for _, alias := range def.Aliases {
+			s.aliases[alias.Name] = def
+		}
+		// The objects of the factories are created by the NewX methods.
+		// They can not be used to fill other definitions.
+		// The decorators are used through the definitions they decorate.
+		if !def.Def.NotForAutoFill && !def.IsFactory() && def.Def.Decorates == "" {
 			s.defsByType[def.ObjectTypeString] = append(s.defsByType[def.ObjectTypeString], def)
+			s.autofillDefs = append(s.autofillDefs, def)
 		}
 	}
 
+	// link the decorators to the definitions they decorate
+	errs := s.scanDecorators()
+
 	// scan parameters
 	for _, def := range scan.Defs {
-		if err := s.scanParams(def); err != nil {
-			return errors.New("could not scan parameters for definition " + def.Name + ": " + err.Error())
+		errs = append(errs, s.scanParams(def)...)
+	}
+
+	errs = append(errs, s.checkDecoratedParams()...)
+
+	s.setContextAware()
+
+	errs = append(errs, s.checkFactories()...)
+	errs = append(errs, s.checkContextMethods()...)
+	errs = append(errs, s.checkPrimaries()...)
+	errs = append(errs, s.checkScopes()...)
+	errs = append(errs, s.checkCycles()...)
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// setContextAware marks the definitions that use the context
+// given to the SafeGetXCtx methods, directly or through their dependencies.
+// Lazy dependencies are retrieved after the build, so they do not receive the context.
+// Factories are excluded because the NewX methods do not take a context.
+func (s *ParamScanner) setContextAware() {
+	for changed := true; changed; {
+		changed = false
+
+		for _, def := range s.scan.Defs {
+			if !def.ContextAware && !def.IsFactory() && s.usesContext(def) {
+				def.ContextAware = true
+				changed = true
+			}
+		}
+	}
+}
+
+// usesContext returns true if a parameter of the definition receives the context,
+// or if it depends on a context aware definition.
+func (s *ParamScanner) usesContext(def *ScannedDef) bool {
+	for _, param := range def.Params {
+		if param.Context {
+			return true
+		}
+		if param.Lazy {
+			continue
+		}
+		if param.Decorated && def.Decorates != nil {
+			if def.Decorates.ContextAware {
+				return true
+			}
+			continue
+		}
+		for _, name := range param.Dependencies() {
+			if dep, ok := s.defsByName[name]; ok && dep.Outermost().ContextAware {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkContextMethods returns an error for each definition that uses a context
+// if the name of its SafeGetXCtx method is already used by another definition.
+func (s *ParamScanner) checkContextMethods() DefErrors {
+	errs := DefErrors{}
+	defsByFormattedName := map[string]*ScannedDef{}
+
+	for _, def := range s.scan.Defs {
+		defsByFormattedName[def.FormattedName] = def
+		for _, alias := range def.Aliases {
+			defsByFormattedName[alias.FormattedName] = def
+		}
+	}
+
+	for _, def := range s.scan.Defs {
+		if other, ok := defsByFormattedName[def.FormattedName+"Ctx"]; ok && def.UsesContext() {
+			errs = append(errs, toDefError(errors.New("the methods generated to use the context collide with those of definition "+other.Name),
+				CodeInvalidName, def.Name, ""))
+		}
+	}
+
+	return errs
+}
+
+// scanDecorators links the decorators to the definitions they decorate.
+// The decorators of a definition are sorted by Order and name.
+// It returns an error for each decorator that can not wrap its definition.
+func (s *ParamScanner) scanDecorators() DefErrors {
+	errs := DefErrors{}
+	decorators := map[string][]*ScannedDef{}
+
+	for _, def := range s.scan.Defs {
+		if def.Def.Decorates == "" {
+			continue
+		}
+		if err := s.checkDecorator(def); err != nil {
+			errs = append(errs, toDefError(err, CodeInvalidDecorates, def.Name, ""))
+			continue
 		}
+		decorators[def.Def.Decorates] = append(decorators[def.Def.Decorates], def)
+	}
+
+	for _, decorated := range s.scan.Defs {
+		defs := decorators[decorated.Name]
+		if len(defs) == 0 {
+			continue
+		}
+
+		if other, ok := s.lookup(decoratedDiName(decorated.Name)); ok {
+			errs = append(errs, toDefError(errors.New("the name is used internally because "+decorated.Name+" is decorated"),
+				CodeInvalidName, other.Name, ""))
+			continue
+		}
+
+		sortDefs(defs)
+
+		decorated.Decorators = defs
+
+		for i, def := range defs {
+			if i == 0 {
+				def.Decorates = decorated
+			} else {
+				def.Decorates = defs[i-1]
+			}
+		}
+	}
+
+	return errs
+}
+
+// checkDecorator returns an error if the decorator can not wrap the definition it decorates.
+// The decorator must have the type of the decorated definition (or implement it if it is an interface),
+// so that it can replace the decorated object everywhere.
+func (s *ParamScanner) checkDecorator(def *ScannedDef) error {
+	decorated, ok := s.defsByName[def.Def.Decorates]
+
+	switch {
+	case !ok:
+		return errors.New("could not find definition " + def.Def.Decorates + " to decorate")
+	case decorated == def:
+		return errors.New("a definition can not decorate itself")
+	case decorated.Def.Decorates != "":
+		return errors.New("definition " + decorated.Name + " is already a decorator, use Order to stack the decorators of " +
+			decorated.Def.Decorates)
+	case def.IsFactory() || decorated.IsFactory():
+		return errors.New("factories can not be decorated and can not be decorators")
+	case s.scan.ScopeLevel(def.Scope) != s.scan.ScopeLevel(decorated.Scope):
+		return errors.New("the decorator should have the same scope as " + decorated.Name)
+	case def.ObjectType != decorated.ObjectType && !s.implementsInterface(def.ObjectType, decorated.ObjectType):
+		return errors.New("the decorator should be a " + decorated.ObjectTypeString + " to decorate " + decorated.Name +
+			" but is a " + def.ObjectTypeString)
 	}
 
 	return nil
 }
 
-func (s *ParamScanner) scanParams(def *ScannedDef) error {
-	params, err := s.expectedParams(def)
-	if err != nil {
-		return err
+// checkDecoratedParams returns an error for each decorator
+// that does not have a parameter to receive the decorated object.
+func (s *ParamScanner) checkDecoratedParams() DefErrors {
+	errs := DefErrors{}
+
+	for _, def := range s.scan.Defs {
+		if def.Decorates == nil || def.decoratedParam() != nil {
+			continue
+		}
+		errs = append(errs, toDefError(errors.New("the decorator should have a parameter of type "+
+			s.defsByName[def.Def.Decorates].ObjectTypeString+" or a parameter filled with dingo.Decorated"),
+			CodeInvalidDecorates, def.Name, ""))
+	}
+
+	return errs
+}
+
+// receivesDecorated returns true if the parameter of the decorator
+// should receive the decorated object even if it is not in the definition Params.
+// It is the case for the first parameter with the type of the decorated definition
+// if dingo.Decorated is not used in the definition Params.
+func (s *ParamScanner) receivesDecorated(param *ParamInfo, def *ScannedDef) bool {
+	if def.Decorates == nil || def.decoratedParam() != nil {
+		return false
+	}
+	for _, p := range def.Def.Params {
+		if _, ok := p.(DecoratedParam); ok {
+			return false
+		}
+	}
+	return param.Type == s.defsByName[def.Def.Decorates].ObjectType
+}
+
+// setDecoratedParam fills the parameter of the decorator
+// with the object of the definition it wraps.
+func (s *ParamScanner) setDecoratedParam(param *ParamInfo, def *ScannedDef) error {
+	if def.Decorates == nil {
+		return errors.New("param " + param.Name + " can not be filled with dingo.Decorated because the definition is not a decorator")
+	}
+	if other := def.decoratedParam(); other != nil {
+		return errors.New("param " + param.Name + " can not be filled with dingo.Decorated because param " +
+			other.Name + " already receives the decorated object")
+	}
+
+	inner := def.Decorates
+
+	if inner.ObjectType != param.Type && !s.implementsInterface(inner.ObjectType, param.Type) {
+		return errors.New("param " + param.Name + " should be a " + param.TypeString + " but is a " + inner.ObjectTypeString)
+	}
+
+	param.Decorated = true
+	param.ServiceName = inner.Name
+
+	return nil
+}
+
+// checkFactories returns an error for each factory
+// whose arguments can not be used in the generated NewX method.
+// The names of the arguments must be unique and must not shadow
+// the packages and the variables used in the method.
+func (s *ParamScanner) checkFactories() DefErrors {
+	errs := DefErrors{}
+
+	reserved := map[string]bool{"c": true, "i": true, "f": true, "ok": true, "err": true, "eo": true}
+	for name := range reservedPkgNames {
+		reserved[name] = true
+	}
+	for _, alias := range s.scan.TypeManager.Imports() {
+		reserved[alias] = true
+	}
+
+	for _, def := range s.scan.Defs {
+		seen := map[string]bool{}
+
+		for _, arg := range def.Args() {
+			if reserved[arg.Arg] {
+				errs = append(errs, toDefError(errors.New("argument name "+arg.Arg+" is reserved in the generated code"),
+					CodeInvalidParam, def.Name, arg.Name))
+				continue
+			}
+			if seen[arg.Arg] {
+				errs = append(errs, toDefError(errors.New("argument "+arg.Arg+" is used more than once"),
+					CodeInvalidParam, def.Name, arg.Name))
+				continue
+			}
+			seen[arg.Arg] = true
+		}
+	}
+
+	return errs
+}
+
+// checkPrimaries returns an error for each primary definition
+// that has the same type as another primary definition,
+// or that can not be used for autofill.
+func (s *ParamScanner) checkPrimaries() DefErrors {
+	errs := DefErrors{}
+	primaries := map[string]*ScannedDef{}
+
+	for _, def := range s.scan.Defs {
+		if !def.Def.Primary {
+			continue
+		}
+		if def.Def.NotForAutoFill {
+			errs = append(errs, toDefError(errors.New("a definition can not be primary and not for autofill"),
+				CodeInvalidPrimary, def.Name, ""))
+			continue
+		}
+		if other, ok := primaries[def.ObjectTypeString]; ok {
+			errs = append(errs, toDefError(errors.New("definition "+other.Name+" is already the primary definition for "+def.ObjectTypeString),
+				CodeInvalidPrimary, def.Name, ""))
+			continue
+		}
+		primaries[def.ObjectTypeString] = def
+	}
+
+	return errs
+}
+
+// checkScopes returns an error for each definition that depends on
+// a service that belongs to a narrower scope.
+func (s *ParamScanner) checkScopes() DefErrors {
+	errs := DefErrors{}
+
+	for _, def := range s.scan.Defs {
+		level := s.scan.ScopeLevel(def.Scope)
+
+		for _, param := range def.sortedParams() {
+			for _, name := range param.Dependencies() {
+				dep, ok := s.defsByName[name]
+				if !ok {
+					continue
+				}
+				depLevel := s.scan.ScopeLevel(dep.Scope)
+				if depLevel <= level {
+					continue
+				}
+				errs = append(errs, toDefError(errors.New("the definition scope is "+s.scan.Scopes[level]+
+					", it can not depend on "+dep.Name+" that belongs to the narrower scope "+s.scan.Scopes[depLevel]),
+					CodeScopeMismatch, def.Name, param.Name))
+			}
+		}
 	}
 
+	return errs
+}
+
+// scanParams sets the params of the definition.
+// It returns all the problems found in the params.
+func (s *ParamScanner) scanParams(def *ScannedDef) DefErrors {
+	params, errs := s.expectedParams(def)
+
 	def.Params = params
 
+	names := make([]string, 0, len(def.Def.Params))
 	for name := range def.Def.Params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
 		if _, ok := params[name]; !ok {
-			return errors.New("definition should not have parameter " + name)
+			errs = append(errs, toDefError(errors.New("definition should not have parameter "+name),
+				CodeUnknownParam, def.Name, name))
 		}
 	}
 
-	for _, param := range params {
+	for _, param := range def.sortedParams() {
 		if err := s.setParam(param, def); err != nil {
-			return err
+			errs = append(errs, toDefError(err, CodeInvalidParam, def.Name, param.Name))
 		}
 	}
 
-	return nil
+	return errs
 }
 
-func (s *ParamScanner) expectedParams(def *ScannedDef) (map[string]*ParamInfo, error) {
+func (s *ParamScanner) expectedParams(def *ScannedDef) (map[string]*ParamInfo, DefErrors) {
 	if def.BuildIsFunc {
 		return s.expectedFuncParams(def)
 	}
+	if def.BuildIsZero() {
+		return map[string]*ParamInfo{}, nil
+	}
 	return s.expectedStructParams(def)
 }
 
-func (s *ParamScanner) expectedFuncParams(def *ScannedDef) (map[string]*ParamInfo, error) {
+func (s *ParamScanner) expectedFuncParams(def *ScannedDef) (map[string]*ParamInfo, DefErrors) {
 	params := map[string]*ParamInfo{}
+	errs := DefErrors{}
 
 	t := reflect.TypeOf(def.Def.Build)
 
@@ -82,7 +406,8 @@ func (s *ParamScanner) expectedFuncParams(def *ScannedDef) (map[string]*ParamInf
 
 		pType, err := s.scan.TypeManager.Register(t.In(i))
 		if err != nil {
-			return nil, err
+			errs = append(errs, toDefError(err, CodeInvalidParam, def.Name, index))
+			continue
 		}
 
 		params[index] = &ParamInfo{
@@ -90,54 +415,178 @@ func (s *ParamScanner) expectedFuncParams(def *ScannedDef) (map[string]*ParamInf
 			Index:      index,
 			Type:       t.In(i),
 			TypeString: pType,
+			Variadic:   t.IsVariadic() && i == t.NumIn()-1,
 			Def:        def,
 		}
 	}
 
-	return params, nil
+	return params, errs
 }
 
-func (s *ParamScanner) expectedStructParams(def *ScannedDef) (map[string]*ParamInfo, error) {
+func (s *ParamScanner) expectedStructParams(def *ScannedDef) (map[string]*ParamInfo, DefErrors) {
 	params := map[string]*ParamInfo{}
 
-	t := reflect.TypeOf(def.Def.Build).Elem()
+	def.EmbeddedFields = map[string]*EmbeddedField{}
+
+	t := reflect.TypeOf(def.Def.Build)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	errs := s.addStructParams(def, params, t, "", "", map[reflect.Type]bool{})
+
+	return params, errs
+}
+
+// addStructParams adds the exported fields of the structure t in the params.
+// The fields of the embedded structures are added with their name prefixed
+// by the name of the embedded field (e.g. "Base.Logger").
+// The parents contain the embedded structures that are being scanned,
+// to avoid infinite recursions.
+func (s *ParamScanner) addStructParams(def *ScannedDef, params map[string]*ParamInfo, t reflect.Type,
+	prefix, indexPrefix string, parents map[reflect.Type]bool) DefErrors {
+	errs := DefErrors{}
+
+	parents[t] = true
+	defer delete(parents, t)
 
 	for i := 0; i < t.NumField(); i++ {
 		f := t.Field(i)
 
-		if f.Name != strings.Title(f.Name) {
+		// The field is unexported, or it is an embedded field with an unexported type.
+		// It can not be set from the generated package.
+		if f.PkgPath != "" {
 			continue
 		}
 
-		index := strconv.Itoa(i)
+		name := prefix + f.Name
+		index := indexPrefix + strconv.Itoa(i)
+
+		if s.isPromotedStruct(def, f, name, parents) {
+			structType := f.Type
+			if structType.Kind() == reflect.Ptr {
+				structType = structType.Elem()
+			}
+
+			typeString, err := s.scan.TypeManager.Register(structType)
+			if err != nil {
+				errs = append(errs, toDefError(err, CodeInvalidParam, def.Name, name))
+				continue
+			}
+
+			def.EmbeddedFields[name] = &EmbeddedField{
+				Name:       name,
+				Index:      index,
+				TypeString: typeString,
+				Ptr:        f.Type.Kind() == reflect.Ptr,
+			}
+
+			errs = append(errs, s.addStructParams(def, params, structType, name+".", index+"_", parents)...)
+			continue
+		}
 
 		pType, err := s.scan.TypeManager.Register(f.Type)
 		if err != nil {
-			return nil, err
+			errs = append(errs, toDefError(err, CodeInvalidParam, def.Name, name))
+			continue
 		}
 
-		params[f.Name] = &ParamInfo{
-			Name:       f.Name,
+		params[name] = &ParamInfo{
+			Name:       name,
 			Index:      index,
 			Type:       f.Type,
 			TypeString: pType,
+			FieldTag:   f.Tag,
 			Def:        def,
 		}
 	}
 
-	return params, nil
+	return errs
+}
+
+// isPromotedStruct returns true if the fields of the embedded structure
+// should be filled instead of the embedded field itself.
+// The embedded field is filled as a whole if it is in the definition Params,
+// if it has a dingo tag, or if a definition has its type.
+func (s *ParamScanner) isPromotedStruct(def *ScannedDef, f reflect.StructField, name string, parents map[reflect.Type]bool) bool {
+	if !f.Anonymous {
+		return false
+	}
+
+	t := f.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || parents[t] {
+		return false
+	}
+
+	if _, ok := def.Def.Params[name]; ok {
+		return false
+	}
+	if _, ok := f.Tag.Lookup("dingo"); ok {
+		return false
+	}
+
+	for _, d := range s.autofillDefs {
+		if d.ObjectType == f.Type {
+			return false
+		}
+	}
+
+	return true
 }
 
 func (s *ParamScanner) setParam(param *ParamInfo, def *ScannedDef) error {
 	p, ok := def.Def.Params[param.Name]
+	if !ok && s.receivesDecorated(param, def) {
+		return s.setDecoratedParam(param, def)
+	}
 	if !ok {
-		return s.autofill(param, !def.BuildIsFunc)
+		return s.setTagParam(param, def)
+	}
+
+	if _, ok := p.(DecoratedParam); ok {
+		return s.setDecoratedParam(param, def)
 	}
 
 	if v, ok := p.(Service); ok {
 		return s.setServiceParam(param, string(v))
 	}
 
+	if v, ok := p.(OptionalService); ok {
+		return s.setOptionalServiceParam(param, string(v))
+	}
+
+	if v, ok := p.(ArgParam); ok {
+		return s.setArgParam(param, string(v))
+	}
+
+	if v, ok := p.(LazyService); ok {
+		return s.setLazyParam(param, string(v))
+	}
+
+	if _, ok := p.(OptionalAutoFill); ok {
+		param.Optional = true
+		return s.autofill(param, true)
+	}
+
+	if v, ok := p.([]Service); ok {
+		return s.setServicesParam(param, v)
+	}
+
+	if _, ok := p.(ContextParam); ok {
+		return s.setContextParam(param)
+	}
+
+	if _, ok := p.(AllServices); ok {
+		return s.setAllParam(param)
+	}
+
+	if v, ok := p.(TaggedServices); ok {
+		return s.setTaggedParam(param, string(v))
+	}
+
 	autofill, ok := p.(AutoFill)
 	if ok && bool(autofill) {
 		return s.autofill(param, false)
@@ -146,6 +595,7 @@ func (s *ParamScanner) setParam(param *ParamInfo, def *ScannedDef) error {
 		return errors.New("definition can not have parameters with AutoFill(false) because it uses a Build function")
 	}
 	if ok {
+		param.UndefinedStructParam = true
 		return nil
 	}
 
@@ -161,39 +611,551 @@ func (s *ParamScanner) setParam(param *ParamInfo, def *ScannedDef) error {
 	return nil
 }
 
+// setTagParam sets a parameter that is not in the definition Params.
+// The dingo tag of the structure field is used if there is one.
+// Otherwise the parameter is autofilled.
+func (s *ParamScanner) setTagParam(param *ParamInfo, def *ScannedDef) error {
+	tag, err := parseDingoTag(param.FieldTag)
+	if err != nil {
+		return err
+	}
+
+	param.Optional = tag.optional
+
+	// The context.Context at the beginning of a Build function receives the build context.
+	if def.BuildIsFunc && param.Index == "0" && param.Type == contextType {
+		return s.setContextParam(param)
+	}
+
+	if tag.skip || tag.noAutoFill {
+		param.UndefinedStructParam = true
+		return nil
+	}
+
+	if tag.service == "" {
+		// The variadic parameter of a Build function can be empty.
+		return s.autofill(param, !def.BuildIsFunc || param.Variadic)
+	}
+
+	if tag.optional {
+		return s.setOptionalServiceParam(param, tag.service)
+	}
+
+	return s.setServiceParam(param, tag.service)
+}
+
 func (s *ParamScanner) autofill(param *ParamInfo, acceptNotFound bool) error {
+	return s.autofillParam(param, acceptNotFound, false)
+}
+
+// autofillParam is like autofill, but if acceptAmbiguous and acceptNotFound are true,
+// the parameter also keeps its zero value when several definitions have its type.
+func (s *ParamScanner) autofillParam(param *ParamInfo, acceptNotFound, acceptAmbiguous bool) error {
 	defs := s.defsByType[param.TypeString]
+	rule := "type"
+	tag, _ := parseDingoTag(param.FieldTag)
+	if len(defs) == 0 && lazyElemType(param.Type) != nil {
+		return s.autofillLazy(param, acceptNotFound)
+	}
+	if len(defs) == 0 && tag.name == "" && s.isMultiCandidate(param) {
+		param.AutoFilled = true
+		param.AutoFillRule = "all"
+		return s.setAllParam(param)
+	}
+	if len(defs) == 0 && isNonEmptyInterface(param.Type) {
+		defs = s.matchingDefs(param.Type, param.Def)
+		rule = "interface"
+	}
+	// The name of the dingo tag is always used, even if there is only one definition.
+	if len(defs) > 1 || tag.name != "" {
+		named, err := s.namedDefs(param, defs)
+		if err != nil {
+			return err
+		}
+		if len(named) == 1 {
+			defs = named
+			rule = "name"
+		}
+	}
+	if len(defs) > 1 {
+		primaries := primaryDefs(defs)
+		if len(primaries) > 1 {
+			return newDefError(CodeInvalidPrimary, fmt.Sprintf("autofill require at most one primary %s, but found %d (%s)",
+				param.TypeString, len(primaries), strings.Join(defNames(primaries), ", ")))
+		}
+		if len(primaries) == 1 {
+			defs = primaries
+			rule = "primary"
+		}
+	}
 	if len(defs) == 0 && acceptNotFound {
 		param.UndefinedStructParam = true
 		return nil
 	}
+	// A structure field is not filled if its interface is ambiguous, as if there was no definition.
+	if len(defs) > 1 && acceptNotFound && (rule == "interface" || acceptAmbiguous) {
+		param.UndefinedStructParam = true
+		return nil
+	}
 	if len(defs) == 0 {
-		return fmt.Errorf("autofill require exactly one %s, but found 0 definition with this type", param.TypeString)
+		return newDefError(CodeAutoFill, fmt.Sprintf("autofill require exactly one %s, but found 0 definition with this type", param.TypeString))
+	}
+	if len(defs) > 1 && rule == "interface" {
+		return newDefError(CodeAutoFill, fmt.Sprintf("autofill require exactly one %s, but found %d definitions implementing this interface (%s)",
+			param.TypeString, len(defs), strings.Join(defNames(defs), ", ")))
 	}
 	if len(defs) > 1 {
-		return fmt.Errorf("autofill require exactly one %s, but found %d definitions with this type", param.TypeString, len(defs))
+		return newDefError(CodeAutoFill, fmt.Sprintf("autofill require exactly one %s, but found %d definitions with this type", param.TypeString, len(defs)))
 	}
 
 	param.ServiceName = defs[0].Name
+	param.AutoFilled = true
+	param.AutoFillRule = rule
 
 	return nil
 }
 
+// namedDefs returns the definitions whose name matches the parameter.
+// The name can be given with the dingo tag of the structure field (`dingo:"name"`).
+// Otherwise, if the definition uses AutoFillByName, the definitions
+// whose formatted name matches the field name are returned.
+func (s *ParamScanner) namedDefs(param *ParamInfo, defs []*ScannedDef) ([]*ScannedDef, error) {
+	named := []*ScannedDef{}
+
+	if tag, _ := parseDingoTag(param.FieldTag); tag.name != "" {
+		name := tag.name
+		for _, def := range defs {
+			if def.Name == name {
+				return append(named, def), nil
+			}
+		}
+		if len(defs) == 0 {
+			return nil, newDefError(CodeAutoFill, "the dingo tag refers to "+name+", but there is no definition that could autofill "+param.TypeString)
+		}
+		return nil, newDefError(CodeAutoFill, "the dingo tag refers to "+name+", but it is not one of the definitions that could autofill "+
+			param.TypeString+" ("+strings.Join(defNames(defs), ", ")+")")
+	}
+
+	if !param.Def.Def.AutoFillByName || param.Def.BuildIsFunc {
+		return named, nil
+	}
+
+	fieldName := param.Name[strings.LastIndex(param.Name, ".")+1:]
+
+	for _, def := range defs {
+		if strings.EqualFold(def.FormattedName, fieldName) {
+			named = append(named, def)
+		}
+	}
+
+	return named, nil
+}
+
+// primaryDefs returns the definitions with the Primary flag.
+func primaryDefs(defs []*ScannedDef) []*ScannedDef {
+	primaries := []*ScannedDef{}
+	for _, def := range defs {
+		if def.Def.Primary {
+			primaries = append(primaries, def)
+		}
+	}
+	return primaries
+}
+
+// lookup returns the definition with the given name or alias.
+func (s *ParamScanner) lookup(name string) (*ScannedDef, bool) {
+	if def, ok := s.defsByName[name]; ok {
+		return def, true
+	}
+	def, ok := s.aliases[name]
+	return def, ok
+}
+
+// setServiceParam fills the parameter with the given service.
+// If the service is an alias, the name of the definition is used.
 func (s *ParamScanner) setServiceParam(param *ParamInfo, service string) error {
-	def, ok := s.defsByName[service]
+	def, ok := s.lookup(service)
 	if !ok {
-		return errors.New("could not find definition " + service + " for param " + param.Name)
+		return newDefError(CodeUnknownService, "could not find definition "+service+" for param "+param.Name)
+	}
+
+	if def.IsFactory() {
+		return errors.New("param " + param.Name + " can not be filled with " + service + " because it is a factory")
 	}
 
 	if def.ObjectTypeString != param.TypeString && !s.implementsInterface(def.ObjectType, param.Type) {
 		return errors.New("param " + param.Name + " should be a " + param.TypeString + " but is a " + def.ObjectTypeString)
 	}
 
-	param.ServiceName = service
+	param.ServiceName = def.Name
 
 	return nil
 }
 
+// isMultiCandidate returns true if the parameter is a slice
+// that can be automatically filled with all the services of its element type.
+// Slices of empty interfaces are excluded because every service would match.
+func (s *ParamScanner) isMultiCandidate(param *ParamInfo) bool {
+	if param.Type.Kind() != reflect.Slice {
+		return false
+	}
+	elem := param.Type.Elem()
+	if elem.Kind() == reflect.Interface && !isNonEmptyInterface(elem) {
+		return false
+	}
+	return len(s.matchingDefs(elem, param.Def)) > 0
+}
+
+// isNonEmptyInterface returns true if the type is an interface with at least one method.
+// Every type implements the empty interface, so it can not be used to select definitions.
+func isNonEmptyInterface(t reflect.Type) bool {
+	return t.Kind() == reflect.Interface && t.NumMethod() > 0
+}
+
+func defNames(defs []*ScannedDef) []string {
+	names := make([]string, 0, len(defs))
+	for _, def := range defs {
+		names = append(names, def.Name)
+	}
+	return names
+}
+
+// setAllParam fills a slice parameter with all the services
+// whose type is (or implements) the type of the slice elements.
+func (s *ParamScanner) setAllParam(param *ParamInfo) error {
+	if param.Type.Kind() != reflect.Slice {
+		return errors.New("param " + param.Name + " should be a slice to be filled with dingo.All() but is a " + param.TypeString)
+	}
+
+	elemType, err := s.scan.TypeManager.Register(param.Type.Elem())
+	if err != nil {
+		return err
+	}
+
+	param.Multi = true
+	param.ElemTypeString = elemType
+	param.ServiceNames = []string{}
+
+	for _, def := range s.matchingDefs(param.Type.Elem(), param.Def) {
+		param.ServiceNames = append(param.ServiceNames, def.Name)
+	}
+
+	return nil
+}
+
+// setOptionalServiceParam is similar to setServiceParam,
+// but the parameter keeps its zero value if the service is not defined.
+func (s *ParamScanner) setOptionalServiceParam(param *ParamInfo, service string) error {
+	param.Optional = true
+
+	if _, ok := s.lookup(service); !ok {
+		param.UndefinedStructParam = true
+		return nil
+	}
+
+	return s.setServiceParam(param, service)
+}
+
+// setArgParam makes the parameter an argument of the NewX method of the factory.
+func (s *ParamScanner) setArgParam(param *ParamInfo, name string) error {
+	if !token.IsIdentifier(name) || types.Universe.Lookup(name) != nil {
+		return errors.New("argument name " + name + " is not a valid identifier")
+	}
+
+	param.Arg = name
+
+	return nil
+}
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// lazyElemType returns T if t is a func() (T, error) or a dingo.Lazy[T].
+// Otherwise it returns nil.
+func lazyElemType(t reflect.Type) reflect.Type {
+	if t.Kind() != reflect.Func || t.NumIn() != 0 || t.NumOut() != 2 || t.Out(1) != errorType {
+		return nil
+	}
+	return t.Out(0)
+}
+
+// lazyElemParam returns a copy of the lazy parameter
+// where the type is replaced by the type T of the returned service.
+func (s *ParamScanner) lazyElemParam(param *ParamInfo) (*ParamInfo, error) {
+	t := lazyElemType(param.Type)
+	if t == nil {
+		return nil, errors.New("param " + param.Name + " should be a func() (T, error) or a dingo.Lazy[T] to be filled with dingo.LazyService but is a " + param.TypeString)
+	}
+
+	typeString, err := s.scan.TypeManager.Register(t)
+	if err != nil {
+		return nil, err
+	}
+
+	elem := *param
+	elem.Type = t
+	elem.TypeString = typeString
+
+	return &elem, nil
+}
+
+// setLazyParam fills the parameter with a function
+// that retrieves the given service on its first call.
+func (s *ParamScanner) setLazyParam(param *ParamInfo, service string) error {
+	elem, err := s.lazyElemParam(param)
+	if err != nil {
+		return err
+	}
+
+	if err := s.setServiceParam(elem, service); err != nil {
+		return err
+	}
+
+	param.Lazy = true
+	param.ServiceName = elem.ServiceName
+	param.ElemTypeString = elem.TypeString
+
+	return nil
+}
+
+// autofillLazy fills the parameter with a function that retrieves
+// the service that would autofill a parameter of type T.
+func (s *ParamScanner) autofillLazy(param *ParamInfo, acceptNotFound bool) error {
+	elem, err := s.lazyElemParam(param)
+	if err != nil {
+		return err
+	}
+
+	// A lazy structure field is not filled if the service is ambiguous.
+	if err := s.autofillParam(elem, acceptNotFound, true); err != nil {
+		return err
+	}
+
+	if elem.UndefinedStructParam {
+		param.UndefinedStructParam = true
+		return nil
+	}
+	if elem.Multi || elem.Lazy {
+		return newDefError(CodeAutoFill, "autofill can not fill "+param.TypeString+" lazily because "+elem.TypeString+" is not filled with a single service")
+	}
+
+	param.Lazy = true
+	param.ServiceName = elem.ServiceName
+	param.ElemTypeString = elem.TypeString
+	param.AutoFilled = true
+	param.AutoFillRule = elem.AutoFillRule
+
+	return nil
+}
+
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+// setContextParam fills the parameter with the context
+// given to the SafeGetXCtx methods.
+func (s *ParamScanner) setContextParam(param *ParamInfo) error {
+	if param.Type != contextType {
+		return errors.New("param " + param.Name + " should be a context.Context to be filled with dingo.Context but is a " + param.TypeString)
+	}
+
+	param.Context = true
+
+	return nil
+}
+
+// setServicesParam fills a slice parameter with the given services.
+// The services must have the type of the slice elements (or implement it).
+func (s *ParamScanner) setServicesParam(param *ParamInfo, services []Service) error {
+	if param.Type.Kind() != reflect.Slice {
+		return errors.New("param " + param.Name + " should be a slice to be filled with a []dingo.Service but is a " + param.TypeString)
+	}
+
+	elemType, err := s.scan.TypeManager.Register(param.Type.Elem())
+	if err != nil {
+		return err
+	}
+
+	param.Multi = true
+	param.ElemTypeString = elemType
+	param.ServiceNames = []string{}
+
+	for _, service := range services {
+		def, ok := s.lookup(string(service))
+		if !ok {
+			return newDefError(CodeUnknownService, "could not find definition "+string(service)+" for param "+param.Name)
+		}
+		if def.IsFactory() {
+			return errors.New("param " + param.Name + " can not contain " + def.Name + " because it is a factory")
+		}
+		if def.ObjectType != param.Type.Elem() && !s.implementsInterface(def.ObjectType, param.Type.Elem()) {
+			return errors.New("param " + param.Name + " can not contain " + def.Name +
+				" because its type is " + def.ObjectTypeString + " and not " + elemType)
+		}
+		param.ServiceNames = append(param.ServiceNames, def.Name)
+	}
+
+	return nil
+}
+
+// setTaggedParam fills a slice parameter with all the services tagged with the given name.
+// The tagged services must have the type of the slice elements (or implement it).
+func (s *ParamScanner) setTaggedParam(param *ParamInfo, name string) error {
+	if param.Type.Kind() != reflect.Slice {
+		return errors.New("param " + param.Name + " should be a slice to be filled with dingo.Tagged() but is a " + param.TypeString)
+	}
+
+	elemType, err := s.scan.TypeManager.Register(param.Type.Elem())
+	if err != nil {
+		return err
+	}
+
+	param.Multi = true
+	param.Tag = name
+	param.ElemTypeString = elemType
+	param.ServiceNames = []string{}
+
+	for _, tag := range s.scan.Tags {
+		if tag.Name != name {
+			continue
+		}
+		for _, def := range tag.Defs {
+			if def.ObjectType != param.Type.Elem() && !s.implementsInterface(def.ObjectType, param.Type.Elem()) {
+				return errors.New("param " + param.Name + " can not contain " + def.Name + " tagged with " + name +
+					" because its type is " + def.ObjectTypeString + " and not " + elemType)
+			}
+			param.ServiceNames = append(param.ServiceNames, def.Name)
+		}
+	}
+
+	return nil
+}
+
+// matchingDefs returns the definitions available for autofill
+// whose type is (or is offered as) the given type, sorted by Order and name.
+// The definition that needs the services is excluded.
+func (s *ParamScanner) matchingDefs(t reflect.Type, exclude *ScannedDef) []*ScannedDef {
+	defs := []*ScannedDef{}
+
+	for _, def := range s.autofillDefs {
+		if def != exclude && (def.ObjectType == t || s.isOfferedAs(def, t)) {
+			defs = append(defs, def)
+		}
+	}
+
+	sortDefs(defs)
+
+	return defs
+}
+
+// sortDefs sorts the definitions by Order and name.
+func sortDefs(defs []*ScannedDef) {
+	sort.SliceStable(defs, func(i, j int) bool {
+		if defs[i].Def.Order != defs[j].Def.Order {
+			return defs[i].Def.Order < defs[j].Def.Order
+		}
+		return defs[i].Name < defs[j].Name
+	})
+}
+
+// isOfferedAs returns true if the definition can be autofilled as the interface t.
+// If the definition declares interfaces in Def.As, t must be one of them.
+func (s *ParamScanner) isOfferedAs(def *ScannedDef, t reflect.Type) bool {
+	if len(def.AsTypes) == 0 {
+		return s.implementsInterface(def.ObjectType, t)
+	}
+	for _, as := range def.AsTypes {
+		if as == t {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *ParamScanner) implementsInterface(t, i reflect.Type) bool {
 	return i.Kind() == reflect.Interface && t.Implements(i)
 }
+
+// dependencyEdge is a step in a dependency path.
+// The definition depends on another service because of the given param.
+type dependencyEdge struct {
+	def   *ScannedDef
+	param *ParamInfo
+}
+
+// checkCycles returns an error for each dependency cycle.
+// A definition that depends on itself, directly or through other definitions,
+// creates a cycle. The error contains the complete path of the cycle.
+func (s *ParamScanner) checkCycles() DefErrors {
+	errs := DefErrors{}
+	visited := map[string]bool{}
+
+	for _, def := range s.scan.Defs {
+		cycle := s.findCycle(def, visited, nil)
+		if cycle == nil {
+			continue
+		}
+
+		// Each cycle should only be reported once.
+		for _, edge := range cycle {
+			visited[edge.def.Name] = true
+		}
+
+		errs = append(errs, toDefError(errors.New("dependency cycle detected: "+formatCycle(cycle)),
+			CodeCycle, cycle[0].def.Name, cycle[0].param.Name))
+	}
+
+	return errs
+}
+
+// findCycle explores the dependencies of the definition with a depth-first search.
+// The path contains the edges that lead to the definition.
+// The returned slice is nil if no cycle was found,
+// otherwise it contains the edges of the cycle.
+func (s *ParamScanner) findCycle(def *ScannedDef, visited map[string]bool, path []dependencyEdge) []dependencyEdge {
+	for i, edge := range path {
+		if edge.def.Name == def.Name {
+			return path[i:]
+		}
+	}
+
+	if visited[def.Name] {
+		return nil
+	}
+
+	for _, param := range def.sortedParams() {
+		// Lazy parameters retrieve their service after the object is built.
+		// They can not create a cycle.
+		if param.Lazy {
+			continue
+		}
+
+		for _, name := range param.Dependencies() {
+			dep, ok := s.defsByName[name]
+			if !ok {
+				continue
+			}
+
+			// The other definitions get the object of the outermost decorator.
+			if !param.Decorated {
+				dep = dep.Outermost()
+			}
+
+			cycle := s.findCycle(dep, visited, append(path, dependencyEdge{def: def, param: param}))
+			if cycle != nil {
+				return cycle
+			}
+		}
+	}
+
+	visited[def.Name] = true
+
+	return nil
+}
+
+func formatCycle(cycle []dependencyEdge) string {
+	str := ""
+
+	for _, edge := range cycle {
+		str += edge.def.Name + " (param " + edge.param.Name + ") -> "
+	}
+
+	return str + cycle[0].def.Name
+}
@@ -16,6 +16,15 @@ type Provider interface {
 	Get(name string) (*Def, error)
 }
 
+// ScopeProvider can be implemented by a Provider
+// to declare the scopes that are used by the container.
+// The scopes should be ordered from the widest to the narrowest.
+// If the Provider does not implement this interface,
+// di.App, di.Request and di.SubRequest are used.
+type ScopeProvider interface {
+	Scopes() []string
+}
+
 // BaseProvider implements the Provider interface.
 // It contains no definition, but you can use this
 // to create your own Provider by redefining the Load method.
@@ -83,7 +92,16 @@ func (p *BaseProvider) Add(i interface{}) error {
 }
 
 // AddDef is the same as Add, but only for Def.
+// If the definition Location is empty, it is set to the place where the definition is added.
 func (p *BaseProvider) AddDef(def Def) error {
+	if def.Location == "" {
+		def.Location = callerLocation()
+	}
+	return p.addDef(def)
+}
+
+// addDef adds the definition without changing its Location.
+func (p *BaseProvider) addDef(def Def) error {
 	if p.defs == nil {
 		p.defs = map[string]*Def{}
 	}
@@ -100,9 +118,11 @@ func (p *BaseProvider) AddDefPtr(def *Def) error {
 }
 
 // AddDefSlice is the same as Add, but only for []Def.
+// The place where the slice is added is not a useful Location for its definitions,
+// so it is not recorded. dingo.Declare can be used instead.
 func (p *BaseProvider) AddDefSlice(defs []Def) error {
 	for _, def := range defs {
-		if err := p.AddDef(def); err != nil {
+		if err := p.addDef(def); err != nil {
 			return err
 		}
 	}
@@ -110,9 +130,10 @@ func (p *BaseProvider) AddDefSlice(defs []Def) error {
 }
 
 // AddDefPtrSlice is the same as Add, but only for []*Def.
+// Like AddDefSlice, it does not record the Location of the definitions.
 func (p *BaseProvider) AddDefPtrSlice(defs []*Def) error {
 	for _, def := range defs {
-		if err := p.AddDefPtr(def); err != nil {
+		if err := p.addDef(*def); err != nil {
 			return err
 		}
 	}
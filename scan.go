@@ -1,6 +1,8 @@
 package dingo
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"reflect"
 	"sort"
@@ -15,21 +17,271 @@ type Scan struct {
 	Defs                 []*ScannedDef
 	ProviderPackage      string
 	ProviderName         string
+	Scopes               []string
+	Tags                 []*ScannedTag
+	ContextTypeString    string
+}
+
+// ScannedTag contains the definitions sharing a tag.
+// The definitions are sorted by priority, order and name.
+// TypeString is the common type of the definitions,
+// or interface{} if their types differ.
+type ScannedTag struct {
+	Name          string
+	FormattedName string
+	TypeString    string
+	Defs          []*ScannedDef
+}
+
+// ServiceNames returns the names of the tagged definitions.
+func (tag *ScannedTag) ServiceNames() []string {
+	names := make([]string, 0, len(tag.Defs))
+	for _, def := range tag.Defs {
+		names = append(names, def.Name)
+	}
+	return names
+}
+
+// GenerateComment returns the text used in the comments of the generated code.
+func (tag *ScannedTag) GenerateComment() string {
+	names, _ := json.Marshal(tag.ServiceNames())
+	return "\t\t// \tservices: " + strings.ReplaceAll(string(names), ",", ", ")
+}
+
+// Fingerprints returns the fingerprint of each definition.
+// The key of the map is the definition name.
+func (scan *Scan) Fingerprints() map[string]string {
+	fingerprints := make(map[string]string, len(scan.Defs))
+
+	for _, def := range scan.Defs {
+		fingerprints[def.Name] = def.Fingerprint()
+	}
+
+	return fingerprints
+}
+
+// UsesCleanups returns true if a Build function returns a cleanup function.
+func (scan *Scan) UsesCleanups() bool {
+	for _, def := range scan.Defs {
+		if def.BuildCleanupTypeString != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// UsesContexts returns true if a definition uses the context given to the SafeGetXCtx methods.
+func (scan *Scan) UsesContexts() bool {
+	for _, def := range scan.Defs {
+		if def.ContextAware {
+			return true
+		}
+	}
+	return false
+}
+
+// ScopeLevel returns the position of the given scope in the scope list.
+// The empty scope is the widest scope.
+// It returns -1 if the scope does not exist.
+func (scan *Scan) ScopeLevel(scope string) int {
+	if scope == "" {
+		return 0
+	}
+	for i, s := range scan.Scopes {
+		if s == scope {
+			return i
+		}
+	}
+	return -1
 }
 
 // ScannedDef contains the parsed information about a service definition.
+// BuildReturnsError is true if the Build function returns an error.
+// BuildCleanupTypeString is the type of the cleanup function
+// returned by the Build function ("func()" or "func() error"),
+// or an empty string if there is none.
+// Decorates is the definition wrapped by a decorator:
+// the decorated definition or the previous decorator.
+// Decorators contains the decorators of a decorated definition,
+// from the innermost to the outermost.
+// Aliases are the other names of the definition (see Def.Aliases).
+// ContextAware is true if the definition uses the context given to the SafeGetXCtx methods,
+// directly or through its dependencies. Its objects are then built by a dingo.ContextBuilder.
 type ScannedDef struct {
-	Def              *Def
-	Name             string
-	FormattedName    string
-	Scope            string
-	ObjectType       reflect.Type
-	ObjectTypeString string
-	BuildIsFunc      bool
-	BuildTypeString  string
-	Params           map[string]*ParamInfo
-	CloseTypeString  string
-	Unshared         bool
+	Def                    *Def
+	Name                   string
+	FormattedName          string
+	Scope                  string
+	ObjectType             reflect.Type
+	ObjectTypeString       string
+	BuildIsFunc            bool
+	BuildIsValue           bool
+	BuildTypeString        string
+	BuildReturnsError      bool
+	BuildCleanupTypeString string
+	Params                 map[string]*ParamInfo
+	CloseTypeString        string
+	Unshared               bool
+	AsTypes                []reflect.Type
+	EmbeddedFields         map[string]*EmbeddedField
+	Decorates              *ScannedDef
+	Decorators             []*ScannedDef
+	Aliases                []*ScannedAlias
+	ContextAware           bool
+}
+
+// ScannedAlias is another name of a definition.
+// FormattedName is used in the names of the generated methods.
+type ScannedAlias struct {
+	Name          string
+	FormattedName string
+}
+
+// DiName returns the name of the generated di.Def.
+// The name of a decorated definition is used by the di.Def
+// that returns the object of the outermost decorator.
+// The decorated definition itself uses an internal name.
+func (def *ScannedDef) DiName() string {
+	if len(def.Decorators) > 0 {
+		return decoratedDiName(def.Name)
+	}
+	return def.Name
+}
+
+// decoratedParam returns the parameter of a decorator
+// that receives the decorated object, or nil if there is none.
+func (def *ScannedDef) decoratedParam() *ParamInfo {
+	for _, param := range def.Params {
+		if param.Decorated {
+			return param
+		}
+	}
+	return nil
+}
+
+// decoratedDiName returns the internal name
+// of the di.Def of a decorated definition.
+func decoratedDiName(name string) string {
+	return name + "#decorated"
+}
+
+// Outermost returns the outermost decorator of the definition,
+// or the definition itself if it is not decorated.
+func (def *ScannedDef) Outermost() *ScannedDef {
+	if len(def.Decorators) > 0 {
+		return def.Decorators[len(def.Decorators)-1]
+	}
+	return def
+}
+
+// UsesContext returns true if the object retrieved with the definition name
+// is built with the context given to the SafeGetXCtx methods.
+// In this case these methods are generated.
+func (def *ScannedDef) UsesContext() bool {
+	return def.Outermost().ContextAware
+}
+
+// HasClose returns true if the objects are closed
+// with the Close function or the cleanup function returned by the Build function.
+func (def *ScannedDef) HasClose() bool {
+	return def.CloseTypeString != "" || def.BuildCleanupTypeString != ""
+}
+
+// ContextBuilderDiName returns the name of the di.Def
+// that stores the dingo.ContextBuilder of a context aware definition.
+func (def *ScannedDef) ContextBuilderDiName() string {
+	return def.DiName() + "#context"
+}
+
+// IsFactory returns true if at least one parameter is filled with dingo.Arg.
+// In this case, the objects are created by the generated NewX method.
+func (def *ScannedDef) IsFactory() bool {
+	for _, p := range def.Def.Params {
+		if _, ok := p.(ArgParam); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Args returns the parameters filled with dingo.Arg, sorted by index.
+func (def *ScannedDef) Args() []*ParamInfo {
+	args := []*ParamInfo{}
+
+	for _, param := range def.Params {
+		if param.Arg != "" {
+			args = append(args, param)
+		}
+	}
+
+	sort.Slice(args, func(i, j int) bool {
+		return compareIndexes(args[i].Index, args[j].Index) < 0
+	})
+
+	return args
+}
+
+// FactoryParamsString returns the parameters of the function
+// that creates the objects of a factory, as they should appear in a go file.
+func (def *ScannedDef) FactoryParamsString() string {
+	params := []string{}
+	for _, arg := range def.Args() {
+		params = append(params, "p"+arg.Index+" "+arg.TypeString)
+	}
+	return strings.Join(params, ", ")
+}
+
+// FactoryArgsString returns the arguments of the NewX method of a factory.
+// If withTypes is false, only their names are returned.
+func (def *ScannedDef) FactoryArgsString(withTypes bool) string {
+	args := []string{}
+	for _, arg := range def.Args() {
+		if withTypes {
+			args = append(args, arg.Arg+" "+arg.TypeString)
+		} else {
+			args = append(args, arg.Arg)
+		}
+	}
+	return strings.Join(args, ", ")
+}
+
+// FactoryTypeString returns the type of the function
+// that creates the objects of a factory.
+func (def *ScannedDef) FactoryTypeString() string {
+	types := []string{}
+	for _, arg := range def.Args() {
+		types = append(types, arg.TypeString)
+	}
+	return "func(" + strings.Join(types, ", ") + ") (" + def.ObjectTypeString + ", error)"
+}
+
+// BuildIsZero returns true if the object is the zero value of a type that is not a structure.
+// In this case, the definition has no params.
+func (def *ScannedDef) BuildIsZero() bool {
+	return def.BuildIsValue && def.ObjectType.Kind() != reflect.Struct
+}
+
+// BuildKind returns how the object is created: "func" (Build function),
+// "struct" (structure or pointer to a structure) or "zero" (zero value of a named type).
+func (def *ScannedDef) BuildKind() string {
+	if def.BuildIsFunc {
+		return "func"
+	}
+	if def.BuildIsZero() {
+		return "zero"
+	}
+	return "struct"
+}
+
+// EmbeddedField is a structure embedded in a Build structure.
+// Its fields are filled like the fields of the Build structure.
+// Their param names are prefixed by the Name of the EmbeddedField (e.g. "Base.Logger").
+// TypeString is the type of the embedded structure, without the pointer if Ptr is true.
+type EmbeddedField struct {
+	Name       string
+	Index      string
+	TypeString string
+	Ptr        bool
 }
 
 // ParamsString returns the parameters as they should appear
@@ -42,18 +294,82 @@ func (def *ScannedDef) ParamsString() string {
 			params[i] = "p" + strconv.Itoa(i)
 		}
 
+		if len(params) > 0 && reflect.TypeOf(def.Def.Build).IsVariadic() {
+			params[len(params)-1] += "..."
+		}
+
 		return strings.Join(params, ", ")
 	}
 
-	params := ""
+	return def.structParamsString("")
+}
+
+// structParamsString returns the fields of the structure
+// whose params are prefixed by the given prefix.
+// The embedded structures are written as nested composite literals.
+// The fields are sorted by index, so the generated code is always the same.
+func (def *ScannedDef) structParamsString(prefix string) string {
+	type field struct {
+		index string
+		code  string
+	}
+
+	fields := []field{}
 
 	for _, param := range def.Params {
-		params += param.Name + `: p` + param.Index + ",\n"
+		if name := strings.TrimPrefix(param.Name, prefix); isDirectField(param.Name, prefix) {
+			fields = append(fields, field{index: param.Index, code: name + `: p` + param.Index + ",\n"})
+		}
+	}
+
+	for _, embedded := range def.EmbeddedFields {
+		if !isDirectField(embedded.Name, prefix) {
+			continue
+		}
+		code := strings.TrimPrefix(embedded.Name, prefix) + ": "
+		if embedded.Ptr {
+			code += "&"
+		}
+		code += embedded.TypeString + "{\n" + def.structParamsString(embedded.Name+".") + "},\n"
+		fields = append(fields, field{index: embedded.Index, code: code})
+	}
+
+	sort.Slice(fields, func(i, j int) bool {
+		return compareIndexes(fields[i].index, fields[j].index) < 0
+	})
+
+	params := ""
+
+	for _, f := range fields {
+		params += f.code
 	}
 
 	return params
 }
 
+// isDirectField returns true if the name is the prefix followed by a field name without any dot.
+func isDirectField(name, prefix string) bool {
+	return strings.HasPrefix(name, prefix) && !strings.Contains(strings.TrimPrefix(name, prefix), ".")
+}
+
+// compareIndexes compares two param indexes.
+// The index of a field in an embedded structure is the index of the embedded field,
+// followed by an underscore and the index of the field in the embedded structure (e.g. "1_0").
+func compareIndexes(a, b string) int {
+	partsA := strings.Split(a, "_")
+	partsB := strings.Split(b, "_")
+
+	for i := 0; i < len(partsA) && i < len(partsB); i++ {
+		x, _ := strconv.Atoi(partsA[i])
+		y, _ := strconv.Atoi(partsB[i])
+		if x != y {
+			return x - y
+		}
+	}
+
+	return len(partsA) - len(partsB)
+}
+
 // BuildDependsOnRawDef returns true if the service constructor
 // needs the definition contained in the Provider.
 func (def *ScannedDef) BuildDependsOnRawDef() bool {
@@ -61,13 +377,80 @@ func (def *ScannedDef) BuildDependsOnRawDef() bool {
 		return true
 	}
 	for _, param := range def.Params {
-		if param.ServiceName == "" && !param.UndefinedStructParam {
+		if param.IsValue() {
 			return true
 		}
 	}
 	return false
 }
 
+// sortedParams returns the params sorted by name.
+func (def *ScannedDef) sortedParams() []*ParamInfo {
+	params := make([]*ParamInfo, 0, len(def.Params))
+
+	for _, param := range def.Params {
+		params = append(params, param)
+	}
+
+	sort.Slice(params, func(i, j int) bool {
+		return params[i].Name < params[j].Name
+	})
+
+	return params
+}
+
+// Fingerprint returns a hash of the information used to generate the code of the definition
+// (name, scope, types, params, etc.). It changes if the generated code needs to be updated.
+// It does not depend on the values of the params, as they are read at runtime.
+func (def *ScannedDef) Fingerprint() string {
+	data := []string{
+		"name:" + def.Name,
+		"scope:" + def.Scope,
+		"type:" + def.ObjectType.String(),
+		"build:" + reflect.TypeOf(def.Def.Build).String(),
+		"unshared:" + strconv.FormatBool(def.Unshared),
+	}
+
+	if def.Def.Close != nil {
+		data = append(data, "close:"+reflect.TypeOf(def.Def.Close).String())
+	}
+
+	if def.Def.Decorates != "" {
+		data = append(data, "decorates:"+def.Def.Decorates)
+	}
+
+	for _, decorator := range def.Decorators {
+		data = append(data, "decorator:"+decorator.Name)
+	}
+
+	for _, alias := range def.Aliases {
+		data = append(data, "alias:"+alias.Name)
+	}
+
+	for _, as := range def.AsTypes {
+		data = append(data, "as:"+as.String())
+	}
+
+	for _, tag := range def.Def.Tags {
+		args := make([]string, 0, len(tag.Args))
+		for k, v := range tag.Args {
+			args = append(args, k+"="+v)
+		}
+		sort.Strings(args)
+		data = append(data, "tag:"+tag.Name+":"+strings.Join(args, ","))
+	}
+
+	for _, param := range def.sortedParams() {
+		data = append(data, "param:"+param.Name+":"+param.Type.String()+":"+strings.Join(param.Dependencies(), ",")+
+			":"+strconv.FormatBool(param.Multi)+":"+param.Tag+":"+strconv.FormatBool(param.UndefinedStructParam)+
+			":"+strconv.FormatBool(param.Context)+":"+strconv.FormatBool(param.Optional)+":"+strconv.FormatBool(param.Lazy)+":"+param.Arg+":"+strconv.FormatBool(param.Decorated))
+	}
+
+	hash := sha256.Sum256([]byte(strings.Join(data, "\n")))
+
+	return hex.EncodeToString(hash[:8])
+}
+
 // GenerateCommentScope returns the scope as it should be printed in the generated comments.
 func (def *ScannedDef) GenerateCommentScope() string {
 	if def.Scope == "" {
@@ -106,10 +489,33 @@ func (def *ScannedDef) GenerateCommentParams() string {
 		k, _ := json.Marshal(key)
 
 		comment += "\t\t// \t\t- " + string(k) + ": "
-		if p.ServiceName != "" {
+		if p.Multi && p.Tag != "" {
+			tag, _ := json.Marshal(p.Tag)
+			names, _ := json.Marshal(p.ServiceNames)
+			comment += "Tagged(" + strings.ReplaceAll(p.TypeString, "\n", "") + ") " + string(tag)
+			comment += " " + strings.ReplaceAll(string(names), ",", ", ") + "\n"
+		} else if p.Multi {
+			names, _ := json.Marshal(p.ServiceNames)
+			comment += "Services(" + strings.ReplaceAll(p.TypeString, "\n", "") + ")"
+			comment += " " + strings.ReplaceAll(string(names), ",", ", ") + p.GenerateCommentAutoFill() + "\n"
+		} else if p.Lazy {
+			name, _ := json.Marshal(p.ServiceName)
+			comment += "Lazy(" + strings.ReplaceAll(p.ElemTypeString, "\n", "") + ")"
+			comment += " [" + string(name) + "]" + p.GenerateCommentAutoFill() + p.GenerateCommentOptional() + "\n"
+		} else if p.Decorated {
+			name, _ := json.Marshal(p.ServiceName)
+			comment += "Decorated(" + strings.ReplaceAll(p.TypeString, "\n", "") + ") [" + string(name) + "]\n"
+		} else if p.ServiceName != "" {
 			name, _ := json.Marshal(p.ServiceName)
 			comment += "Service(" + strings.ReplaceAll(p.TypeString, "\n", "") + ")"
-			comment += " [" + string(name) + "]\n"
+			comment += " [" + string(name) + "]" + p.GenerateCommentAutoFill() + p.GenerateCommentOptional() + "\n"
+		} else if p.Arg != "" {
+			arg, _ := json.Marshal(p.Arg)
+			comment += "Arg(" + strings.ReplaceAll(p.TypeString, "\n", "") + ") " + string(arg) + "\n"
+		} else if p.Context {
+			comment += "Context(" + strings.ReplaceAll(p.TypeString, "\n", "") + ")\n"
+		} else if p.UndefinedStructParam {
+			comment += "Zero(" + strings.ReplaceAll(p.TypeString, "\n", "") + ")" + p.GenerateCommentOptional() + "\n"
 		} else {
 			comment += "Value(" + strings.ReplaceAll(p.TypeString, "\n", "") + ")\n"
 		}
@@ -130,20 +536,53 @@ func (def *ScannedDef) GenerateComment() string {
 	comment += "\t\t// \ttype: " + strings.ReplaceAll(def.ObjectTypeString, "\n", "") + "\n"
 	comment += "\t\t// \tscope: " + string(scope) + "\n"
 
-	if def.BuildIsFunc {
-		comment += "\t\t// \tbuild: func" + "\n"
-	} else {
-		comment += "\t\t// \tbuild: struct" + "\n"
+	if def.Def.Location != "" {
+		location, _ := json.Marshal(moduleRelativeLocation(def.Def.Location))
+		comment += "\t\t// \tlocation: " + string(location) + "\n"
+	}
+
+	comment += "\t\t// \tbuild: " + def.BuildKind() + "\n"
+
+	if def.IsFactory() {
+		comment += "\t\t// \tfactory: true\n"
+	}
+
+	if def.Decorates != nil {
+		decorates, _ := json.Marshal(def.Def.Decorates)
+		comment += "\t\t// \tdecorates: " + string(decorates) + "\n"
+	}
+
+	if len(def.Decorators) > 0 {
+		names, _ := json.Marshal(defNames(def.Decorators))
+		comment += "\t\t// \tdecorators: " + strings.ReplaceAll(string(names), ",", ", ") + "\n"
+	}
+
+	if len(def.Aliases) > 0 {
+		aliases := make([]string, 0, len(def.Aliases))
+		for _, alias := range def.Aliases {
+			aliases = append(aliases, alias.Name)
+		}
+		names, _ := json.Marshal(aliases)
+		comment += "\t\t// \taliases: " + strings.ReplaceAll(string(names), ",", ", ") + "\n"
 	}
 
 	comment += def.GenerateCommentParams()
 
+	if len(def.Def.Tags) > 0 {
+		tags := make([]string, 0, len(def.Def.Tags))
+		for _, tag := range def.Def.Tags {
+			tags = append(tags, tag.Name)
+		}
+		names, _ := json.Marshal(tags)
+		comment += "\t\t// \ttags: " + strings.ReplaceAll(string(names), ",", ", ") + "\n"
+	}
+
 	if def.Unshared {
 		comment += "\t\t// \tunshared: true" + "\n"
 	} else {
 		comment += "\t\t// \tunshared: false" + "\n"
 	}
-	if def.Def.Close != nil {
+	if def.Def.Close != nil || def.BuildCleanupTypeString != "" {
 		comment += "\t\t// \tclose: true" + "\n"
 	} else {
 		comment += "\t\t// \tclose: false" + "\n"
@@ -155,12 +594,95 @@ func (def *ScannedDef) GenerateComment() string {
 }
 
 // ParamInfo contains the parsed information about a parameter.
+// If Multi is true, the parameter is a slice filled
+// with the services listed in ServiceNames.
+// Tag is set if these services were selected with Tagged.
+// If AutoFilled is true, AutoFillRule explains how the services were chosen
+// ("type", "interface", "name", "primary" or "all").
+// FieldTag is the tag of the structure field for Build structures.
+// Variadic is true for the last parameter of a variadic Build function.
+// Its type is a slice.
+// Context is true if the parameter receives the context given to the SafeGetXCtx methods.
+// Optional is true if the parameter can be left to its zero value
+// when the service it depends on does not exist.
+// If Lazy is true, the parameter is a func() (T, error) (or a dingo.Lazy[T])
+// that retrieves the service named ServiceName on its first call.
+// ElemTypeString is then the type T.
+// Arg is the name of the argument of the NewX method
+// if the parameter is filled with dingo.Arg.
+// Decorated is true if the parameter of a decorator
+// receives the object of the definition named ServiceName.
 type ParamInfo struct {
 	Name                 string
 	Index                string
 	ServiceName          string
+	ServiceNames         []string
+	Multi                bool
+	Tag                  string
 	Type                 reflect.Type
 	TypeString           string
+	ElemTypeString       string
 	UndefinedStructParam bool
+	FieldTag             reflect.StructTag
+	Variadic             bool
+	Context              bool
+	Optional             bool
+	Lazy                 bool
+	Arg                  string
+	Decorated            bool
+	AutoFilled           bool
+	AutoFillRule         string
 	Def                  *ScannedDef
 }
+
+// IsValue returns true if the parameter value is read from the definition Params.
+func (param *ParamInfo) IsValue() bool {
+	return param.ServiceName == "" && !param.Multi && !param.UndefinedStructParam && !param.Context && param.Arg == ""
+}
+
+// GenerateCommentAutoFill returns the rule used to autofill the parameter
+// as it should be printed in the generated comments.
+func (param *ParamInfo) GenerateCommentAutoFill() string {
+	if !param.AutoFilled {
+		return ""
+	}
+	return " (autofill: " + param.AutoFillRule + ")"
+}
+
+// GenerateCommentOptional returns the optional mark as it should be printed in the generated comments.
+func (param *ParamInfo) GenerateCommentOptional() string {
+	if !param.Optional {
+		return ""
+	}
+	return " (optional)"
+}
+
+// BackgroundString returns the code of context.Background()
+// for a parameter filled with the context.
+// It is used by the factories that do not receive the context of the SafeGetXCtx methods.
+func (param *ParamInfo) BackgroundString() string {
+	return strings.TrimSuffix(param.TypeString, "Context") + "Background()"
+}
+
+// ServiceDiName returns the name of the di.Def used to fill the parameter.
+// It is the ServiceName, except for the parameter of the innermost decorator
+// that receives the object of the decorated definition.
+func (param *ParamInfo) ServiceDiName() string {
+	if param.Decorated && param.Def.Decorates != nil {
+		return param.Def.Decorates.DiName()
+	}
+	return param.ServiceName
+}
+
+// Dependencies returns the names of the services used to fill the parameter.
+// The services of lazy parameters are included,
+// even if they are only retrieved when the parameter is called.
+func (param *ParamInfo) Dependencies() []string {
+	if param.Multi {
+		return param.ServiceNames
+	}
+	if param.ServiceName != "" {
+		return []string{param.ServiceName}
+	}
+	return nil
+}
@@ -4,6 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sarulabs/di/v2"
 )
 
 // Scanner analyzes the definitions provided by a Provider.
@@ -14,6 +19,7 @@ type Scanner struct {
 }
 
 // Scan creates the Scan for the Scanner definitions.
+// The definitions are loaded with a new instance of the Provider.
 func (s *Scanner) Scan() (*Scan, error) {
 	s.scan = &Scan{
 		TypeManager: &TypeManager{},
@@ -24,46 +30,139 @@ func (s *Scanner) Scan() (*Scan, error) {
 		return nil, err
 	}
 
+	return s.scanDefs()
+}
+
+// ScanLoaded is like Scan, but it uses the definitions already loaded in the Provider.
+// Provider.Load is not called again. It is used at runtime
+// to check the fingerprints with the Provider of the generated container.
+func (s *Scanner) ScanLoaded() (*Scan, error) {
+	s.scan = &Scan{
+		TypeManager: &TypeManager{},
+		Defs:        []*ScannedDef{},
+	}
+
+	if err := s.setProviderInfo(); err != nil {
+		return nil, err
+	}
+
+	if err := s.loadScopes(); err != nil {
+		return nil, err
+	}
+
+	return s.scanDefs()
+}
+
+// scanDefs scans the definitions of the loaded Provider.
+func (s *Scanner) scanDefs() (*Scan, error) {
+	errs := DefErrors{}
+
 	for _, name := range s.Provider.Names() {
 		def, err := s.Provider.Get(name)
 		if err != nil {
 			return nil, err
 		}
 
-		if err := s.scanDef(def); err != nil {
-			return nil, errors.New("could not scan definition " + def.Name + ": " + err.Error())
-		}
+		errs = append(errs, s.scanDef(def)...)
 	}
 
+	errs = append(errs, s.checkAliases()...)
+	errs = append(errs, s.scanTags()...)
+
 	s.scan.ImportsWithoutParams = s.scan.TypeManager.Imports()
 
 	if err := s.ParamScanner.Scan(s.scan); err != nil {
-		return nil, err
+		paramErrs, ok := err.(DefErrors)
+		if !ok {
+			return nil, err
+		}
+		errs = append(errs, paramErrs...)
+	}
+
+	// The container needs to import the context package for the SafeGetXCtx methods.
+	if s.scan.UsesContexts() {
+		ctxType, err := s.scan.TypeManager.Register(contextType)
+		if err != nil {
+			return nil, err
+		}
+		s.scan.ContextTypeString = ctxType
+		s.scan.ImportsWithoutParams["context"] = s.scan.TypeManager.Imports()["context"]
+	}
+
+	for _, e := range errs {
+		if def, err := s.Provider.Get(e.DefName); err == nil {
+			e.Location = def.Location
+		}
+	}
+
+	if len(errs) > 0 {
+		sort.SliceStable(errs, func(i, j int) bool {
+			return errs[i].DefName < errs[j].DefName
+		})
+		return nil, errs
 	}
 
 	return s.scan, nil
 }
 
 func (s *Scanner) loadProvider() error {
+	if err := s.setProviderInfo(); err != nil {
+		return err
+	}
+
+	s.Provider = reflect.New(reflect.TypeOf(s.Provider).Elem()).Interface().(Provider)
+
+	if err := s.Provider.Load(); err != nil {
+		return fmt.Errorf("could not load definitions with Provider.Load(): %v", err)
+	}
+
+	return s.loadScopes()
+}
+
+// setProviderInfo sets the package and the name of the Provider structure in the Scan.
+func (s *Scanner) setProviderInfo() error {
 	providerType := reflect.TypeOf(s.Provider)
 
-	if providerType.Kind() != reflect.Ptr || providerType.Elem().Kind() != reflect.Struct {
+	if providerType == nil || providerType.Kind() != reflect.Ptr || providerType.Elem().Kind() != reflect.Struct {
 		return errors.New("the Provider must be a pointer to a structure")
 	}
 
-	s.Provider = reflect.New(providerType.Elem()).Interface().(Provider)
-
 	s.scan.ProviderPackage = providerType.Elem().PkgPath()
 	s.scan.ProviderName = providerType.Elem().Name()
 
-	if err := s.Provider.Load(); err != nil {
-		return fmt.Errorf("could not load definitions with Provider.Load(): %v", err)
+	return nil
+}
+
+func (s *Scanner) loadScopes() error {
+	s.scan.Scopes = []string{di.App, di.Request, di.SubRequest}
+
+	if p, ok := s.Provider.(ScopeProvider); ok {
+		s.scan.Scopes = p.Scopes()
+	}
+
+	if len(s.scan.Scopes) == 0 {
+		return errors.New("the Provider must declare at least one scope")
+	}
+
+	seen := map[string]bool{}
+
+	for _, scope := range s.scan.Scopes {
+		if scope == "" {
+			return errors.New("the Provider can not declare an empty scope")
+		}
+		if seen[scope] {
+			return errors.New("the Provider declares the scope " + scope + " more than once")
+		}
+		seen[scope] = true
 	}
 
 	return nil
 }
 
-func (s *Scanner) scanDef(def *Def) error {
+// scanDef adds the definition to the Scan.
+// It returns all the problems found in the definition.
+// In this case the definition is not added to the Scan.
+func (s *Scanner) scanDef(def *Def) DefErrors {
 	sDef := &ScannedDef{
 		Def:           def,
 		Name:          def.Name,
@@ -72,16 +171,44 @@ func (s *Scanner) scanDef(def *Def) error {
 		Unshared:      def.Unshared,
 	}
 
+	errs := DefErrors{}
+
 	if err := DefNameIsAllowed(sDef.FormattedName); err != nil {
-		return err
+		errs = append(errs, toDefError(err, CodeInvalidName, def.Name, ""))
+	}
+
+	if s.scan.ScopeLevel(def.Scope) < 0 {
+		errs = append(errs, toDefError(errors.New("scope "+def.Scope+" is not declared by the Provider (available scopes: "+
+			strings.Join(s.scan.Scopes, ", ")+")"), CodeInvalidScope, def.Name, ""))
+	}
+
+	if err := s.checkTags(def); err != nil {
+		errs = append(errs, toDefError(err, CodeInvalidTag, def.Name, ""))
+	}
+
+	if err := s.scanAliases(def, sDef); err != nil {
+		errs = append(errs, toDefError(err, CodeInvalidName, def.Name, ""))
 	}
 
 	if err := s.scanBuild(def, sDef); err != nil {
-		return err
+		// The object type is unknown, so the Close function can not be checked.
+		return append(errs, toDefError(err, CodeInvalidBuild, def.Name, ""))
 	}
 
 	if err := s.scanClose(def, sDef); err != nil {
-		return err
+		errs = append(errs, toDefError(err, CodeInvalidClose, def.Name, ""))
+	}
+
+	if err := s.scanAs(def, sDef); err != nil {
+		errs = append(errs, toDefError(err, CodeInvalidAs, def.Name, ""))
+	}
+
+	if sDef.IsFactory() {
+		errs = append(errs, s.checkFactory(def, sDef)...)
+	}
+
+	if len(errs) > 0 {
+		return errs
 	}
 
 	s.scan.Defs = append(s.scan.Defs, sDef)
@@ -89,10 +216,265 @@ func (s *Scanner) scanDef(def *Def) error {
 	return nil
 }
 
+// checkFactory returns the properties of the definition
+// that can not be used because it is a factory.
+// The objects created by a factory are not stored in the container,
+// so they can not be closed, and they can not be retrieved as a service.
+func (s *Scanner) checkFactory(def *Def, scannedDef *ScannedDef) DefErrors {
+	errs := DefErrors{}
+
+	if def.Close != nil {
+		errs = append(errs, toDefError(errors.New("a factory can not have a Close function"), CodeInvalidClose, def.Name, ""))
+	}
+	if scannedDef.BuildCleanupTypeString != "" {
+		errs = append(errs, toDefError(errors.New("the Build function of a factory can not return a cleanup function"), CodeInvalidBuild, def.Name, ""))
+	}
+	if len(def.Tags) > 0 {
+		errs = append(errs, toDefError(errors.New("a factory can not have tags"), CodeInvalidTag, def.Name, ""))
+	}
+	if def.Primary {
+		errs = append(errs, toDefError(errors.New("a factory can not be primary"), CodeInvalidPrimary, def.Name, ""))
+	}
+	if len(def.Aliases) > 0 {
+		errs = append(errs, toDefError(errors.New("a factory can not have aliases"), CodeInvalidName, def.Name, ""))
+	}
+
+	// The types of the arguments are used in the NewX method of the container file.
+	// They are registered now, so that they are part of its imports.
+	names := make([]string, 0, len(def.Params))
+	for name, p := range def.Params {
+		if _, ok := p.(ArgParam); ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		t := paramType(reflect.TypeOf(def.Build), name)
+		if t == nil {
+			continue
+		}
+		if _, err := s.scan.TypeManager.Register(t); err != nil {
+			errs = append(errs, toDefError(err, CodeInvalidParam, def.Name, name))
+		}
+	}
+
+	return errs
+}
+
+// paramType returns the type of the param with the given name
+// for the given Build function or structure.
+// It returns nil if there is no such param.
+func paramType(buildType reflect.Type, name string) reflect.Type {
+	if buildType.Kind() == reflect.Func {
+		i, err := strconv.Atoi(name)
+		if err != nil || i < 0 || i >= buildType.NumIn() {
+			return nil
+		}
+		return buildType.In(i)
+	}
+
+	t := buildType
+
+	for _, fieldName := range strings.Split(name, ".") {
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return nil
+		}
+		f, ok := t.FieldByName(fieldName)
+		if !ok {
+			return nil
+		}
+		t = f.Type
+	}
+
+	return t
+}
+
+func (s *Scanner) scanAs(def *Def, scannedDef *ScannedDef) error {
+	for _, as := range def.As {
+		t := reflect.TypeOf(as)
+
+		if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Interface {
+			return errors.New("the elements of the definition As property should be pointers to interfaces")
+		}
+
+		asType, err := s.scan.TypeManager.Register(t.Elem())
+		if err != nil {
+			return err
+		}
+
+		if !scannedDef.ObjectType.Implements(t.Elem()) {
+			return errors.New("the definition can not be used as " + asType +
+				" because " + scannedDef.ObjectTypeString + " does not implement it")
+		}
+
+		scannedDef.AsTypes = append(scannedDef.AsTypes, t.Elem())
+	}
+
+	return nil
+}
+
+// scanAliases adds the aliases of the definition to the ScannedDef.
+// The collisions with the other definitions are checked by checkAliases.
+func (s *Scanner) scanAliases(def *Def, scannedDef *ScannedDef) error {
+	seen := map[string]bool{def.Name: true}
+
+	for _, alias := range def.Aliases {
+		if err := DefNameIsAllowed(alias); err != nil {
+			return errors.New("alias " + alias + " is not allowed: " + err.Error())
+		}
+		if seen[alias] {
+			return errors.New("alias " + alias + " is used more than once")
+		}
+		seen[alias] = true
+
+		scannedDef.Aliases = append(scannedDef.Aliases, &ScannedAlias{Name: alias, FormattedName: FormatDefName(alias)})
+	}
+
+	return nil
+}
+
+// checkAliases returns an error for each alias that is already
+// the name of a definition or of another alias, or whose generated methods
+// collide with those of a definition or of another alias.
+func (s *Scanner) checkAliases() DefErrors {
+	errs := DefErrors{}
+	names := map[string]string{}
+	formattedNames := map[string]string{}
+
+	for _, def := range s.scan.Defs {
+		names[def.Name] = "definition " + def.Name
+		formattedNames[def.FormattedName] = "definition " + def.Name
+	}
+
+	for _, def := range s.scan.Defs {
+		for _, alias := range def.Aliases {
+			if other, ok := names[alias.Name]; ok {
+				errs = append(errs, toDefError(errors.New("alias "+alias.Name+" is already used by "+other),
+					CodeInvalidName, def.Name, ""))
+				continue
+			}
+			if other, ok := formattedNames[alias.FormattedName]; ok {
+				errs = append(errs, toDefError(errors.New("the methods generated for alias "+alias.Name+" collide with those of "+other),
+					CodeInvalidName, def.Name, ""))
+				continue
+			}
+			names[alias.Name] = "alias " + alias.Name + " of definition " + def.Name
+			formattedNames[alias.FormattedName] = "alias " + alias.Name + " of definition " + def.Name
+		}
+	}
+
+	return errs
+}
+
+func (s *Scanner) checkTags(def *Def) error {
+	seen := map[string]bool{}
+
+	for _, tag := range def.Tags {
+		if FormatDefName(tag.Name) == "" {
+			return errors.New("tag '" + tag.Name + "' is not allowed, it should contain at least one letter or digit")
+		}
+		if seen[tag.Name] {
+			return errors.New("tag " + tag.Name + " is used more than once")
+		}
+		seen[tag.Name] = true
+
+		if _, err := tagPriority(tag); err != nil {
+			return errors.New("tag " + tag.Name + " has an invalid priority, it should be an integer")
+		}
+	}
+
+	return nil
+}
+
+// tagPriority returns the priority argument of the tag (0 if it is not set).
+func tagPriority(tag Tag) (int, error) {
+	priority, ok := tag.Args["priority"]
+	if !ok {
+		return 0, nil
+	}
+	return strconv.Atoi(priority)
+}
+
+// scanTags groups the scanned definitions by tag.
+// It returns an error if the methods generated for a tag
+// collide with those of another tag or of a definition.
+func (s *Scanner) scanTags() DefErrors {
+	errs := DefErrors{}
+	tags := map[string]*ScannedTag{}
+	priorities := map[*ScannedDef]map[string]int{}
+	defsByFormattedName := map[string]*ScannedDef{}
+
+	for _, def := range s.scan.Defs {
+		defsByFormattedName[def.FormattedName] = def
+		for _, alias := range def.Aliases {
+			defsByFormattedName[alias.FormattedName] = def
+		}
+	}
+
+	for _, def := range s.scan.Defs {
+		priorities[def] = map[string]int{}
+
+		for _, t := range def.Def.Tags {
+			priorities[def][t.Name], _ = tagPriority(t)
+
+			tag, ok := tags[t.Name]
+			if !ok {
+				tag = &ScannedTag{Name: t.Name, FormattedName: "AllTagged" + FormatDefName(t.Name)}
+				tags[t.Name] = tag
+				s.scan.Tags = append(s.scan.Tags, tag)
+			}
+
+			tag.Defs = append(tag.Defs, def)
+		}
+	}
+
+	sort.Slice(s.scan.Tags, func(i, j int) bool {
+		return s.scan.Tags[i].Name < s.scan.Tags[j].Name
+	})
+
+	tagsByFormattedName := map[string]*ScannedTag{}
+
+	for _, tag := range s.scan.Tags {
+		sortDefs(tag.Defs)
+		sort.SliceStable(tag.Defs, func(i, j int) bool {
+			return priorities[tag.Defs[i]][tag.Name] > priorities[tag.Defs[j]][tag.Name]
+		})
+
+		tag.TypeString = tag.Defs[0].ObjectTypeString
+		for _, def := range tag.Defs {
+			if def.ObjectTypeString != tag.TypeString {
+				tag.TypeString = "interface{}"
+			}
+		}
+
+		if other, ok := tagsByFormattedName[tag.FormattedName]; ok {
+			errs = append(errs, toDefError(errors.New("tag "+tag.Name+" can not be used because its methods collide with those of tag "+other.Name),
+				CodeInvalidTag, tag.Defs[0].Name, ""))
+		}
+		tagsByFormattedName[tag.FormattedName] = tag
+
+		if def, ok := defsByFormattedName[tag.FormattedName]; ok {
+			errs = append(errs, toDefError(errors.New("tag "+tag.Name+" can not be used because its methods collide with those of definition "+def.Name),
+				CodeInvalidTag, tag.Defs[0].Name, ""))
+		}
+	}
+
+	return errs
+}
+
 func (s *Scanner) scanBuild(def *Def, scannedDef *ScannedDef) error {
+	if def.Build == nil {
+		return errors.New("the definition Build property is not set")
+	}
+
 	t := reflect.TypeOf(def.Build)
 
-	if t.Kind() == reflect.Func {
+	// A nil value of a named function type is a zero value, not a Build function.
+	if t.Kind() == reflect.Func && !(t.PkgPath() != "" && reflect.ValueOf(def.Build).IsNil()) {
 		return s.scanBuildFunc(def, scannedDef, t)
 	}
 
@@ -100,7 +482,14 @@ func (s *Scanner) scanBuild(def *Def, scannedDef *ScannedDef) error {
 		return s.scanBuildStruct(def, scannedDef, t)
 	}
 
-	return errors.New("the definition Build property should be a function or a pointer to a structure")
+	// The predeclared types like string or int have a name but no package.
+	// They are not accepted because their zero value is not a useful service.
+	if t.Kind() == reflect.Struct || t.PkgPath() != "" && t.Kind() != reflect.Ptr && t.Kind() != reflect.Interface {
+		return s.scanBuildValue(def, scannedDef, t)
+	}
+
+	return errors.New("the definition Build property should be a function, a pointer to a structure, " +
+		"a structure or the zero value of a named type")
 }
 
 func (s *Scanner) scanBuildFunc(def *Def, scannedDef *ScannedDef, buildT reflect.Type) error {
@@ -122,24 +511,49 @@ func (s *Scanner) scanBuildFunc(def *Def, scannedDef *ScannedDef, buildT reflect
 	scannedDef.ObjectTypeString = objType
 	scannedDef.BuildIsFunc = true
 	scannedDef.BuildTypeString = BuildTypeString
+	scannedDef.BuildReturnsError = buildT.NumOut() > 1
+
+	if buildT.NumOut() == 3 {
+		scannedDef.BuildCleanupTypeString = buildT.Out(1).String()
+
+		// The cleanup functions are stored by object,
+		// so each object needs its own identity.
+		// The value of an interface is checked when the object is built.
+		objKind := buildT.Out(0).Kind()
+		if objKind != reflect.Ptr && objKind != reflect.Interface {
+			return errors.New("the Build function can not return a cleanup function because " +
+				objType + " is not a pointer or an interface")
+		}
+		if objKind == reflect.Ptr && buildT.Out(0).Elem().Size() == 0 {
+			return errors.New("the Build function can not return a cleanup function because " +
+				objType + " points to a zero-size type")
+		}
+		if def.Close != nil {
+			return errors.New("the Build function can not return a cleanup function because the definition has a Close function")
+		}
+	}
 
 	return nil
 }
 
+// checkBuildFunc checks that the Build function returns
+// T, (T, error), (T, func(), error) or (T, func() error, error).
+// The error can be any type implementing the error interface.
 func (s *Scanner) checkBuildFunc(t reflect.Type) error {
-	if t.IsVariadic() {
-		return errors.New("variadic Build functions are not supported")
-	}
+	errorType := reflect.TypeOf((*error)(nil)).Elem()
+	cleanupTypes := []reflect.Type{reflect.TypeOf(func() {}), reflect.TypeOf(func() error { return nil })}
 
-	if t.NumOut() != 2 {
-		return errors.New("the Build function must have 2 output parameters")
-	}
-
-	if !t.Out(1).Implements(reflect.TypeOf((*error)(nil)).Elem()) {
-		return errors.New("the Build function second output parameter should be an error")
+	switch {
+	case t.NumOut() == 1 && t.Out(0) != errorType:
+		return nil
+	case t.NumOut() == 2 && t.Out(1).Implements(errorType):
+		return nil
+	case t.NumOut() == 3 && t.Out(2).Implements(errorType) && (t.Out(1) == cleanupTypes[0] || t.Out(1) == cleanupTypes[1]):
+		return nil
 	}
 
-	return nil
+	return errors.New("the Build function should return T, (T, error), (T, func(), error) or (T, func() error, error), " +
+		"where error can be any type implementing error")
 }
 
 func (s *Scanner) scanBuildStruct(def *Def, scannedDef *ScannedDef, buildT reflect.Type) error {
@@ -161,6 +575,25 @@ func (s *Scanner) scanBuildStruct(def *Def, scannedDef *ScannedDef, buildT refle
 	return nil
 }
 
+func (s *Scanner) scanBuildValue(def *Def, scannedDef *ScannedDef, buildT reflect.Type) error {
+	if !reflect.ValueOf(def.Build).IsZero() {
+		return errors.New("the definition Build property should be a zero value, the object is created by the container")
+	}
+
+	objType, err := s.scan.TypeManager.Register(buildT)
+	if err != nil {
+		return err
+	}
+
+	scannedDef.ObjectType = buildT
+	scannedDef.ObjectTypeString = objType
+	scannedDef.BuildIsFunc = false
+	scannedDef.BuildIsValue = true
+	scannedDef.BuildTypeString = objType
+
+	return nil
+}
+
 func (s *Scanner) scanClose(def *Def, scannedDef *ScannedDef) error {
 	if def.Close == nil {
 		return nil
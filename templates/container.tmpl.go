@@ -8,6 +8,10 @@ var ContainerTemplate = `
 ############################# */>>>
 
 <<< define "base" ->>>
+	<<<- if .UsesGenerics >>>
+	//go:build go1.18
+
+	<<< end ->>>
 	package <<< .PkgName >>>
 
 	import (
@@ -47,6 +51,14 @@ var ContainerTemplate = `
 		return c
 	}
 
+	// fingerprints contains the fingerprints of the definitions
+	// that were used to generate this container.
+	var fingerprints = map[string]string{
+	<<<- range $name, $fingerprint := .Fingerprints >>>
+		<<< printf "%q" $name >>>: <<< printf "%q" $fingerprint >>>,
+	<<<- end >>>
+	}
+
 	type builder struct {
 		builder *di.Builder
 	}
@@ -58,7 +70,7 @@ var ContainerTemplate = `
 	// But this behavior is not safe, so be sure to know what you are doing.
 	func NewBuilder(scopes ...string) (*builder, error) {
 		if len(scopes) == 0 {
-			scopes = []string{di.App, di.Request, di.SubRequest}
+			scopes = []string{<<< range .Scopes >>><<< printf "%q" . >>>, <<< end >>>}
 		}
 		b, err := di.NewBuilder(scopes...)
 		if err != nil {
@@ -68,6 +80,9 @@ var ContainerTemplate = `
 		if err := provider.Load(); err != nil {
 			return nil, fmt.Errorf("could not load definitions with the Provider (<<< .ProviderName >>> from <<< .ProviderPackage >>>): %v", err)
 		}
+		if err := dingo.CheckFingerprints(provider, fingerprints); err != nil {
+			return nil, err
+		}
 		for _, d := range getDiDefs(provider) {
 			if err := b.Add(d); err != nil {
 				return nil, fmt.Errorf("could not add di.Def in di.Builder: %v", err)
@@ -87,13 +102,39 @@ var ContainerTemplate = `
 		return b.builder.Set(name, obj)
 	}
 
+	// Remove removes one or more definitions from the Builder.
+	// The optional parameters of the other definitions
+	// that depend on the removed definitions keep their zero value.
+	// Other parameters can not be built without the removed definitions.
+	func (b *builder) Remove(names ...string) error {
+		removed := make(map[string]bool, len(names))
+		for _, name := range names {
+			removed[name] = true
+		}
+		nb, err := di.NewBuilder(b.builder.Scopes()...)
+		if err != nil {
+			return err
+		}
+		for name, d := range b.builder.Definitions() {
+			if removed[name] {
+				continue
+			}
+			if err := nb.Add(d); err != nil {
+				return err
+			}
+		}
+		b.builder = nb
+		return nil
+	}
+
 	// Build creates a Container in the most generic scope.
 	func (b *builder) Build() *Container {
 		return &Container{ctn: b.builder.Build()}
 	}
 
 	// NewContainer creates a new Container.
-	// If no scope is provided, di.App, di.Request and di.SubRequest are used.
+	// If no scope is provided, the scopes declared by the Provider are used
+	// (di.App, di.Request and di.SubRequest by default).
 	// The returned Container has the most generic scope (di.App).
 	// The SubContainer() method should be called to get a Container in a more specific scope.
 	func NewContainer(scopes ...string) (*Container, error) {
@@ -226,6 +267,28 @@ var ContainerTemplate = `
 	}
 
 	<<< range $index, $def := .Defs ->>>
+	<<<- if $def.IsFactory >>>
+		// New<<< $def.FormattedName >>> creates a new "<<< $def.Name >>>" object with the given arguments.
+		// The other params are retrieved from the <<< $def.GenerateCommentScope >>> scope.
+		//
+<<< $def.GenerateComment >>>
+		//
+		// The object is not stored in the Container, a new one is created at each call.
+		// If the object can not be created, it returns an error.
+		func (c *Container) New<<< $def.FormattedName >>>(<<< $def.FactoryArgsString true >>>) (<<< $def.ObjectTypeString >>>, error) {
+			i, err := c.ctn.SafeGet("<<< $def.Name >>>")
+			if err != nil {
+				var eo <<< $def.ObjectTypeString >>>
+				return eo, err
+			}
+			f, ok := i.(<<< $def.FactoryTypeString >>>)
+			if !ok {
+				var eo <<< $def.ObjectTypeString >>>
+				return eo, errors.New(<<< printf "could not create '%s' because the factory could not be cast to %s" $def.Name $def.FactoryTypeString | printf "%q" >>>)
+			}
+			return f(<<< $def.FactoryArgsString false >>>)
+		}
+	<<< else >>>
 		// SafeGet<<< $def.FormattedName >>> retrieves the "<<< $def.Name >>>" object from the <<< $def.GenerateCommentScope >>> scope.
 		//
 <<< $def.GenerateComment >>>
@@ -239,7 +302,7 @@ var ContainerTemplate = `
 			}
 			o, ok := i.(<<< $def.ObjectTypeString >>>)
 			if !ok {
-				return o, errors.New("could get '<<< $def.Name >>>' because the object could not be cast to <<< $def.ObjectTypeString >>>")
+				return o, errors.New(<<< printf "could get '%s' because the object could not be cast to %s" $def.Name $def.ObjectTypeString | printf "%q" >>>)
 			}
 			return o, nil
 		}
@@ -257,6 +320,42 @@ var ContainerTemplate = `
 			return o
 		}
 
+		<<<- if $def.UsesContext >>>
+
+		// SafeGet<<< $def.FormattedName >>>Ctx retrieves the "<<< $def.Name >>>" object from the <<< $def.GenerateCommentScope >>> scope.
+		// The context is given to the Build function if the object is built during the call.
+		//
+<<< $def.GenerateComment >>>
+		//
+		// If the object can not be retrieved, it returns an error.
+		func (c *Container) SafeGet<<< $def.FormattedName >>>Ctx(ctx <<< $.ContextTypeString >>>) (<<< $def.ObjectTypeString >>>, error) {
+			i, err := safeGetWithContext(c.ctn, "<<< $def.Name >>>", ctx)
+			if err != nil {
+				var eo <<< $def.ObjectTypeString >>>
+				return eo, err
+			}
+			o, ok := i.(<<< $def.ObjectTypeString >>>)
+			if !ok {
+				return o, errors.New(<<< printf "could get '%s' because the object could not be cast to %s" $def.Name $def.ObjectTypeString | printf "%q" >>>)
+			}
+			return o, nil
+		}
+
+		// Get<<< $def.FormattedName >>>Ctx retrieves the "<<< $def.Name >>>" object from the <<< $def.GenerateCommentScope >>> scope.
+		// The context is given to the Build function if the object is built during the call.
+		//
+<<< $def.GenerateComment >>>
+		//
+		// If the object can not be retrieved, it panics.
+		func (c *Container) Get<<< $def.FormattedName >>>Ctx(ctx <<< $.ContextTypeString >>>) <<< $def.ObjectTypeString >>> {
+			o, err := c.SafeGet<<< $def.FormattedName >>>Ctx(ctx)
+			if err != nil {
+				panic(err)
+			}
+			return o
+		}
+		<<<- end >>>
+
 		// UnscopedSafeGet<<< $def.FormattedName >>> retrieves the "<<< $def.Name >>>" object from the <<< $def.GenerateCommentScope >>> scope.
 		//
 <<< $def.GenerateComment >>>
@@ -271,7 +370,7 @@ var ContainerTemplate = `
 			}
 			o, ok := i.(<<< $def.ObjectTypeString >>>)
 			if !ok {
-				return o, errors.New("could get '<<< $def.Name >>>' because the object could not be cast to <<< $def.ObjectTypeString >>>")
+				return o, errors.New(<<< printf "could get '%s' because the object could not be cast to %s" $def.Name $def.ObjectTypeString | printf "%q" >>>)
 			}
 			return o, nil
 		}
@@ -300,6 +399,82 @@ var ContainerTemplate = `
 		func <<< $def.FormattedName >>>(i interface{}) <<< $def.ObjectTypeString >>> {
 			return C(i).Get<<< $def.FormattedName >>>()
 		}
+		<<<- range $alias := $def.Aliases >>>
+
+		// SafeGet<<< $alias.FormattedName >>> retrieves the "<<< $def.Name >>>" object with its alias "<<< $alias.Name >>>".
+		//
+		// Deprecated: use SafeGet<<< $def.FormattedName >>> instead.
+		func (c *Container) SafeGet<<< $alias.FormattedName >>>() (<<< $def.ObjectTypeString >>>, error) {
+			return c.SafeGet<<< $def.FormattedName >>>()
+		}
+
+		// Get<<< $alias.FormattedName >>> retrieves the "<<< $def.Name >>>" object with its alias "<<< $alias.Name >>>".
+		//
+		// Deprecated: use Get<<< $def.FormattedName >>> instead.
+		func (c *Container) Get<<< $alias.FormattedName >>>() <<< $def.ObjectTypeString >>> {
+			return c.Get<<< $def.FormattedName >>>()
+		}
+
+		// UnscopedSafeGet<<< $alias.FormattedName >>> retrieves the "<<< $def.Name >>>" object with its alias "<<< $alias.Name >>>".
+		//
+		// Deprecated: use UnscopedSafeGet<<< $def.FormattedName >>> instead.
+		func (c *Container) UnscopedSafeGet<<< $alias.FormattedName >>>() (<<< $def.ObjectTypeString >>>, error) {
+			return c.UnscopedSafeGet<<< $def.FormattedName >>>()
+		}
+
+		// UnscopedGet<<< $alias.FormattedName >>> retrieves the "<<< $def.Name >>>" object with its alias "<<< $alias.Name >>>".
+		//
+		// Deprecated: use UnscopedGet<<< $def.FormattedName >>> instead.
+		func (c *Container) UnscopedGet<<< $alias.FormattedName >>>() <<< $def.ObjectTypeString >>> {
+			return c.UnscopedGet<<< $def.FormattedName >>>()
+		}
+
+		// <<< $alias.FormattedName >>> retrieves the "<<< $def.Name >>>" object with its alias "<<< $alias.Name >>>".
+		//
+		// Deprecated: use <<< $def.FormattedName >>> instead.
+		func <<< $alias.FormattedName >>>(i interface{}) <<< $def.ObjectTypeString >>> {
+			return <<< $def.FormattedName >>>(i)
+		}
+		<<<- end >>>
+	<<< end >>>
+	<<< end >>>
+
+	<<< range $index, $tag := .Tags ->>>
+		// SafeGet<<< $tag.FormattedName >>> retrieves the objects tagged with "<<< $tag.Name >>>".
+		// They are sorted by priority, order and name.
+		//
+<<< $tag.GenerateComment >>>
+		//
+		// If one of the objects can not be retrieved, it returns an error.
+		func (c *Container) SafeGet<<< $tag.FormattedName >>>() ([]<<< $tag.TypeString >>>, error) {
+			objects := make([]<<< $tag.TypeString >>>, 0, <<< len $tag.Defs >>>)
+			for _, name := range []string{<<< range $tag.ServiceNames >>><<< printf "%q" . >>>, <<< end >>>} {
+				i, err := c.ctn.SafeGet(name)
+				if err != nil {
+					return nil, err
+				}
+				o, ok := i.(<<< $tag.TypeString >>>)
+				if !ok {
+					return nil, errors.New("could get '" + name + <<< printf "' because the object could not be cast to %s" $tag.TypeString | printf "%q" >>>)
+				}
+				objects = append(objects, o)
+			}
+			return objects, nil
+		}
+
+		// Get<<< $tag.FormattedName >>> retrieves the objects tagged with "<<< $tag.Name >>>".
+		// They are sorted by priority, order and name.
+		//
+<<< $tag.GenerateComment >>>
+		//
+		// If one of the objects can not be retrieved, it panics.
+		func (c *Container) Get<<< $tag.FormattedName >>>() []<<< $tag.TypeString >>> {
+			o, err := c.SafeGet<<< $tag.FormattedName >>>()
+			if err != nil {
+				panic(err)
+			}
+			return o
+		}
 	<<< end >>>
 <<< end >>>
 `
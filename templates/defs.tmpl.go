@@ -8,6 +8,10 @@ var DefsTemplate = `
 ############################# */>>>
 
 <<< define "base" ->>>
+	<<<- if .UsesGenerics >>>
+	//go:build go1.18
+
+	<<< end ->>>
 	package <<< .PkgName >>>
 
 	import (
@@ -19,13 +23,53 @@ var DefsTemplate = `
 		<<< $alias >>> "<<< $pkg >>>"<<< end >>>
 	)
 
+
 	func getDiDefs(provider dingo.Provider) []di.Def {
+		<<<- if .UsesCleanups >>>
+		// cleanups contains the cleanup functions returned by the Build functions.
+		// They are called when the objects are closed.
+		cleanups := &dingo.CleanupRegistry{}
+
+		<<<- end >>>
+		<<<- range $index, $def := .Defs >>>
+		<<<- if $def.ContextAware >>>
+
+		// build<<< $def.FormattedName >>> builds the "<<< $def.Name >>>" object with the given context.
+		build<<< $def.FormattedName >>> := func(ctn di.Container, ctx <<< $.ContextTypeString >>>) (interface{}, error) <<< template "buildBody" $def >>>
+		<<<- end >>>
+		<<<- end >>>
+
 		return []di.Def{
 			<<<- range $index, $def := .Defs ->>>
 				<<< template "definition" $def >>>
 			<<<- end >>>
 		}
 	}
+	<<<- if .UsesContexts >>>
+
+	// safeGetWithContext retrieves an object from the container.
+	// The context is given to the Build functions of the context aware definitions
+	// if their objects are built during the call.
+	func safeGetWithContext(ctn di.Container, name string, ctx <<< .ContextTypeString >>>) (interface{}, error) {
+		switch name {
+		<<<- range $index, $def := .Defs >>>
+		<<<- if $def.ContextAware >>>
+		case "<<< $def.DiName >>>":
+			b, err := dingo.GetContextBuilder(ctn, "<<< $def.ContextBuilderDiName >>>")
+			if err != nil {
+				return nil, err
+			}
+			return b.SafeGet(ctn, name, ctx)
+		<<<- end >>>
+		<<<- if and $def.Decorators $def.UsesContext >>>
+		case "<<< $def.Name >>>":
+			return safeGetWithContext(ctn, "<<< $def.Outermost.Name >>>", ctx)
+		<<<- end >>>
+		<<<- end >>>
+		}
+		return ctn.SafeGet(name)
+	}
+	<<<- end >>>
 <<< end >>>
 
 
@@ -35,14 +79,56 @@ var DefsTemplate = `
 
 <<< define "definition" >>>
 	{
-		Name: "<<< .Name >>>",
+		Name: "<<< .DiName >>>",
 		Scope: "<<< .Scope >>>",
-		Build: func(ctn di.Container) (interface{}, error) <<< template "buildBody" . >>>,
-		<<<-  if ne .CloseTypeString "" >>>
-		Close: func(obj interface{}) error <<< template "closeBody" . >>>,
+		Build: func(ctn di.Container) (interface{}, error) <<< if .IsFactory >>><<< template "factoryBody" . >>><<< else if .ContextAware >>><<< template "contextBody" . >>><<< else >>><<< template "buildBody" . >>><<< end >>>,
+		<<<- if .HasClose >>>
+		Close: <<< template "closeFunc" . >>>,
 		<<<- end >>>
 		Unshared: <<< .Unshared >>>,
+		<<<- if .Def.Tags >>>
+		Tags: []di.Tag{
+			<<<- range .Def.Tags >>>
+			{
+				Name: <<< printf "%q" .Name >>>,
+				<<<- if .Args >>>
+				Args: map[string]string{<<< range $key, $value := .Args >>><<< printf "%q" $key >>>: <<< printf "%q" $value >>>, <<< end >>>},
+				<<<- end >>>
+			},
+			<<<- end >>>
+		},
+		<<<- end >>>
+	},
+	<<<- if .ContextAware >>>
+	{
+		Name: "<<< .ContextBuilderDiName >>>",
+		Scope: "<<< .Scope >>>",
+		Build: func(ctn di.Container) (interface{}, error) {
+			return dingo.NewContextBuilder(ctn, build<<< .FormattedName >>>, <<< .Unshared >>>, <<< if and .Unshared .HasClose >>><<< template "closeFunc" . >>><<< else >>>nil<<< end >>>), nil
+		},
+		Close: dingo.CloseContextBuilder,
 	},
+	<<<- end >>>
+	<<<- if .Decorators >>>
+	{
+		Name: "<<< .Name >>>",
+		Scope: "<<< .Scope >>>",
+		Build: func(ctn di.Container) (interface{}, error) {
+			return ctn.SafeGet("<<< .Outermost.Name >>>")
+		},
+		Unshared: <<< .Outermost.Unshared >>>,
+	},
+	<<<- end >>>
+	<<<- range .Aliases >>>
+	{
+		Name: "<<< .Name >>>",
+		Scope: "<<< $.Scope >>>",
+		Build: func(ctn di.Container) (interface{}, error) {
+			return ctn.SafeGet("<<< $.Name >>>")
+		},
+		Unshared: <<< $.Unshared >>>,
+	},
+	<<<- end >>>
 <<<- end >>>
 
 
@@ -71,16 +157,96 @@ var DefsTemplate = `
 <<<- end >>>
 
 
+<<</* #############################
+###### CONTEXT BODY
+############################# */>>>
+
+<<< define "contextBody" ->>>
+	{
+		b, err := dingo.GetContextBuilder(ctn, "<<< .ContextBuilderDiName >>>")
+		if err != nil {
+			var eo <<< .ObjectTypeString >>>
+			return eo, err
+		}
+		return b.Build()
+	}
+<<<- end >>>
+
+
+<<</* #############################
+###### FACTORY BODY
+############################# */>>>
+
+<<< define "factoryBody" ->>>
+	{
+		return func(<<< .FactoryParamsString >>>) (<<< .ObjectTypeString >>>, error) <<< template "buildBody" . >>>, nil
+	}
+<<<- end >>>
+
+
 <<</* #############################
 ###### BUILD PARAM
 ############################# */>>>
 
 <<< define "buildParam" >>>
-	<<<- if .UndefinedStructParam ->>>
+	<<<- if ne .Arg "" ->>>
+		<<</* The parameter is an argument of the factory function. */>>>
+	<<<- else if .UndefinedStructParam ->>>
 		var p<<< .Index >>> <<< .TypeString >>>
+	<<<- else if and .Context .Def.ContextAware ->>>
+		p<<< .Index >>> := ctx
+	<<<- else if .Context ->>>
+		p<<< .Index >>> := <<< .BackgroundString >>>
+	<<<- else if .Multi ->>>
+		p<<< .Index >>> := make(<<< .TypeString >>>, 0, <<< len .ServiceNames >>>)
+		for _, name := range []string{<<< range .ServiceNames >>><<< printf "%q" . >>>, <<< end >>>} {
+			pi, err := <<< if .Def.ContextAware >>>safeGetWithContext(ctn, name, ctx)<<< else >>>ctn.SafeGet(name)<<< end >>>
+			if err != nil {
+				var eo <<< .Def.ObjectTypeString >>>
+				return eo, err
+			}
+			pe, ok := pi.(<<< .ElemTypeString >>>)
+			if !ok {
+				var eo <<< .Def.ObjectTypeString >>>
+				return eo, errors.New("could not cast service " + name + <<< printf " to %s in parameter %s" .ElemTypeString .Name | printf "%q" >>>)
+			}
+			p<<< .Index >>> = append(p<<< .Index >>>, pe)
+		}
+	<<<- else if .Lazy ->>>
+		pl<<< .Index >>> := dingo.NewLazyResolver(func() (interface{}, error) {
+			return ctn.SafeGet("<<< .ServiceName >>>")
+		})
+		p<<< .Index >>> := func() (<<< .ElemTypeString >>>, error) {
+			pi, err := pl<<< .Index >>>.Resolve()
+			if err != nil {
+				var eo <<< .ElemTypeString >>>
+				return eo, err
+			}
+			pv, ok := pi.(<<< .ElemTypeString >>>)
+			if !ok {
+				var eo <<< .ElemTypeString >>>
+				return eo, errors.New(<<< printf "could not cast service %s to %s in parameter %s" .ServiceName .ElemTypeString .Name | printf "%q" >>>)
+			}
+			return pv, nil
+		}
+	<<<- else if and .Optional (ne .ServiceName "") ->>>
+		var p<<< .Index >>> <<< .TypeString >>>
+		if ctn.NameIsDefined("<<< .ServiceName >>>") {
+			pi, err := <<< if .Def.ContextAware >>>safeGetWithContext(ctn, "<<< .ServiceName >>>", ctx)<<< else >>>ctn.SafeGet("<<< .ServiceName >>>")<<< end >>>
+			if err != nil {
+				var eo <<< .Def.ObjectTypeString >>>
+				return eo, err
+			}
+			pv, ok := pi.(<<< .TypeString >>>)
+			if !ok {
+				var eo <<< .Def.ObjectTypeString >>>
+				return eo, errors.New(<<< printf "could not cast parameter %s to %s" .Name .TypeString | printf "%q" >>>)
+			}
+			p<<< .Index >>> = pv
+		}
 	<<<- else ->>>
 		<<< if ne .ServiceName "" ->>>
-			pi<<< .Index >>>, err := ctn.SafeGet("<<< .ServiceName >>>")
+			pi<<< .Index >>>, err := <<< if .Def.ContextAware >>>safeGetWithContext(ctn, "<<< .ServiceDiName >>>", ctx)<<< else >>>ctn.SafeGet("<<< .ServiceDiName >>>")<<< end >>>
 			if err != nil {
 				var eo <<< .Def.ObjectTypeString >>>
 				return eo, err
@@ -95,7 +261,7 @@ var DefsTemplate = `
 		p<<< .Index >>>, ok := pi<<< .Index >>>.(<<< .TypeString >>>)
 		if !ok {
 			var eo <<< .Def.ObjectTypeString >>>
-			return eo, errors.New("could not cast parameter <<< .Name >>> to <<< .TypeString >>>")
+			return eo, errors.New(<<< printf "could not cast parameter %s to %s" .Name .TypeString | printf "%q" >>>)
 		}
 	<<<- end ->>>
 <<< end >>>
@@ -109,9 +275,35 @@ var DefsTemplate = `
 	b, ok := d.Build.(<<< .BuildTypeString >>>)
 	if !ok {
 		var eo <<< .ObjectTypeString >>>
-		return eo, errors.New("could not cast build function to <<< .BuildTypeString >>>")
+		return eo, errors.New(<<< printf "could not cast build function to %s" .BuildTypeString | printf "%q" >>>)
 	}
-	return b(<<< .ParamsString >>>)
+	<<<- if eq .BuildCleanupTypeString "func()" >>>
+	o, cleanup, buildErr := b(<<< .ParamsString >>>)
+	if buildErr != nil {
+		return o, buildErr
+	}
+	if cleanup == nil {
+		return o, nil
+	}
+	return o, cleanups.Add("<<< .Name >>>", o, func() error {
+		cleanup()
+		return nil
+	})
+	<<<- else if eq .BuildCleanupTypeString "func() error" >>>
+	o, cleanup, buildErr := b(<<< .ParamsString >>>)
+	if buildErr != nil {
+		return o, buildErr
+	}
+	return o, cleanups.Add("<<< .Name >>>", o, cleanup)
+	<<<- else if .BuildReturnsError >>>
+	o, buildErr := b(<<< .ParamsString >>>)
+	if buildErr != nil {
+		return o, buildErr
+	}
+	return o, nil
+	<<<- else >>>
+	return b(<<< .ParamsString >>>), nil
+	<<<- end >>>
 <<<- end >>>
 
 
@@ -120,11 +312,31 @@ var DefsTemplate = `
 ############################# */>>>
 
 <<< define "objectNew" ->>>
-	return &<<< .BuildTypeString >>>{
+	<<<- if .BuildIsZero ->>>
+	var o <<< .ObjectTypeString >>>
+	return o, nil
+	<<<- else ->>>
+	return <<< if not .BuildIsValue >>>&<<< end >>><<< .BuildTypeString >>>{
 		<<< .ParamsString >>>}, nil
+	<<<- end >>>
 <<<- end >>>
 
 
+<<</* #############################
+###### CLOSE FUNC
+############################# */>>>
+
+<<< define "closeFunc" ->>>
+	<<<- if ne .CloseTypeString "" ->>>
+		func(obj interface{}) error <<< template "closeBody" . >>>
+	<<<- else ->>>
+		func(obj interface{}) error {
+			return cleanups.Run("<<< .Name >>>", obj)
+		}
+	<<<- end ->>>
+<<< end >>>
+
+
 <<</* #############################
 ###### CLOSE BODY
 ############################# */>>>
@@ -142,11 +354,11 @@ var DefsTemplate = `
 			}
 			c, ok := d.Close.(<<< .CloseTypeString >>>)
 			if !ok {
-				return errors.New("could not cast close function to '<<< .CloseTypeString >>>'")
+				return errors.New(<<< printf "could not cast close function to '%s'" .CloseTypeString | printf "%q" >>>)
 			}
 			o, ok := obj.(<<< .ObjectTypeString >>>)
 			if !ok {
-				return errors.New("could not cast object to '<<< .ObjectTypeString >>>'")
+				return errors.New(<<< printf "could not cast object to '%s'" .ObjectTypeString | printf "%q" >>>)
 			}
 			return c(o)
 		}
@@ -19,16 +19,11 @@ func WriteTemplate(filename string, tmpl string, data interface{}) error {
 		return fmt.Errorf("mkdir failed: %v", err)
 	}
 
-	content, err := ExecuteTemplate(tmpl, data)
+	content, err := RenderTemplate(filename, tmpl, data)
 	if err != nil {
 		return err
 	}
 
-	content, err = imports.Process(filename, content, nil)
-	if err != nil {
-		return fmt.Errorf("formatting file failed: %v", err)
-	}
-
 	err = ioutil.WriteFile(filename, content, 0664)
 	if err != nil {
 		return fmt.Errorf("writing file failed: %v", err)
@@ -37,6 +32,23 @@ func WriteTemplate(filename string, tmpl string, data interface{}) error {
 	return nil
 }
 
+// RenderTemplate executes the given templates with the given data,
+// and formats the result like WriteTemplate, but without writing any file.
+// The filename is only used to format the imports.
+func RenderTemplate(filename string, tmpl string, data interface{}) ([]byte, error) {
+	content, err := ExecuteTemplate(tmpl, data)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err = imports.Process(filename, content, nil)
+	if err != nil {
+		return nil, fmt.Errorf("formatting file failed: %v", err)
+	}
+
+	return content, nil
+}
+
 // ExecuteTemplate renders the given template.
 func ExecuteTemplate(tmpl string, data interface{}) ([]byte, error) {
 	t, err := template.New("").Delims("<<<", ">>>").Parse(tmpl)
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 
@@ -9,12 +10,28 @@ import (
 )
 
 func main() {
-	if len(os.Args) != 2 {
-		fmt.Println("usage: go run main.go path/to/output/directory")
+	check := flag.Bool("check", false, "only check that the generated container is up to date")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		fmt.Println("usage: go run main.go [-check] path/to/output/directory")
 		os.Exit(1)
 	}
 
-	err := dingo.GenerateContainerWithCustomPkgName((*provider.Provider)(nil), os.Args[1], "dic")
+	if *check {
+		diff, err := dingo.CheckContainer((*provider.Provider)(nil), flag.Arg(0), "dic")
+		if err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+		if !diff.IsEmpty() {
+			fmt.Println(diff.String())
+			os.Exit(1)
+		}
+		return
+	}
+
+	err := dingo.GenerateContainerWithCustomPkgName((*provider.Provider)(nil), flag.Arg(0), "dic")
 	if err != nil {
 		fmt.Println(err.Error())
 		os.Exit(1)
@@ -0,0 +1,11 @@
+package models
+
+// AliasTestService is a structure used in the tests.
+type AliasTestService struct {
+	Value string
+}
+
+// AliasTestConsumer is a structure used in the tests.
+type AliasTestConsumer struct {
+	Service *AliasTestService
+}
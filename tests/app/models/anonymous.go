@@ -0,0 +1,34 @@
+package models
+
+// AnonymousTestCloser is a structure used in the tests.
+type AnonymousTestCloser struct {
+	Closed bool
+}
+
+// Close allows to implement interface{ Close() error }.
+func (c *AnonymousTestCloser) Close() error {
+	c.Closed = true
+	return nil
+}
+
+// AnonymousTestEmbedded is a structure used in the tests.
+type AnonymousTestEmbedded struct {
+	Port int
+}
+
+// AnonymousTestConfig is a structure used in the tests.
+type AnonymousTestConfig struct {
+	Host   string
+	Port   int
+	Logger interface {
+		Logf(format string, args ...interface{}) string
+	}
+}
+
+// AnonymousTestLogger is a structure used in the tests.
+type AnonymousTestLogger struct{}
+
+// Logf allows to implement the interface of AnonymousTestConfig.Logger.
+func (l *AnonymousTestLogger) Logf(format string, args ...interface{}) string {
+	return format
+}
@@ -0,0 +1,42 @@
+package models
+
+import "context"
+
+// ContextTestKey is the type of the context keys used in the tests.
+type ContextTestKey string
+
+// ContextTestClient is a structure used in the tests.
+type ContextTestClient struct {
+	Value  interface{}
+	Closed bool
+}
+
+// NewContextTestClient creates a ContextTestClient
+// with the value of the "key" key of the context.
+func NewContextTestClient(ctx context.Context, name string) (*ContextTestClient, error) {
+	return &ContextTestClient{Value: ctx.Value(ContextTestKey("key"))}, nil
+}
+
+// ContextTestStruct is a structure used in the tests.
+type ContextTestStruct struct {
+	Ctx context.Context
+}
+
+// ContextTestService is a structure used in the tests.
+// It is built with a context and depends on a ContextTestClient.
+type ContextTestService struct {
+	Value  interface{}
+	Client *ContextTestClient
+}
+
+// NewContextTestService creates a ContextTestService
+// with the value of the "key" key of the context.
+func NewContextTestService(ctx context.Context, client *ContextTestClient) *ContextTestService {
+	return &ContextTestService{Value: ctx.Value(ContextTestKey("key")), Client: client}
+}
+
+// ContextTestWrapper is a structure used in the tests.
+// It does not use a context, but its dependency does.
+type ContextTestWrapper struct {
+	Client *ContextTestClient
+}
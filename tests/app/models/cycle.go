@@ -0,0 +1,16 @@
+package models
+
+// CycleTestA is a structure used in the tests.
+type CycleTestA struct {
+	B *CycleTestB
+}
+
+// CycleTestB is a structure used in the tests.
+type CycleTestB struct {
+	C *CycleTestC
+}
+
+// CycleTestC is a structure used in the tests.
+type CycleTestC struct {
+	A *CycleTestA
+}
@@ -0,0 +1,42 @@
+package models
+
+// DecoratorTestStore is an interface used in the tests.
+type DecoratorTestStore interface {
+	Get() string
+}
+
+// DecoratorTestBaseStore is a structure used in the tests.
+// It implements DecoratorTestStore.
+type DecoratorTestBaseStore struct{}
+
+// Get returns "base".
+func (s *DecoratorTestBaseStore) Get() string {
+	return "base"
+}
+
+// NewDecoratorTestStore creates a DecoratorTestBaseStore.
+func NewDecoratorTestStore() DecoratorTestStore {
+	return &DecoratorTestBaseStore{}
+}
+
+// DecoratorTestWrapper is a structure used in the tests.
+// It decorates a DecoratorTestStore.
+type DecoratorTestWrapper struct {
+	Inner DecoratorTestStore
+	Name  string
+}
+
+// Get returns the name of the wrapper followed by the result of the inner store.
+func (s *DecoratorTestWrapper) Get() string {
+	return s.Name + "(" + s.Inner.Get() + ")"
+}
+
+// NewDecoratorTestWrapper creates a DecoratorTestWrapper.
+func NewDecoratorTestWrapper(inner DecoratorTestStore, name string) *DecoratorTestWrapper {
+	return &DecoratorTestWrapper{Inner: inner, Name: name}
+}
+
+// DecoratorTestConsumer is a structure used in the tests.
+type DecoratorTestConsumer struct {
+	Store DecoratorTestStore
+}
@@ -0,0 +1,33 @@
+package models
+
+// EmbeddedTestLogger is a structure used in the tests.
+type EmbeddedTestLogger struct{}
+
+// EmbeddedTestBase is a structure used in the tests.
+type EmbeddedTestBase struct {
+	Logger *EmbeddedTestLogger
+	Name   string
+}
+
+// EmbeddedTestInner is a structure used in the tests.
+type EmbeddedTestInner struct {
+	Value string
+}
+
+// EmbeddedTestMiddle is a structure used in the tests.
+type EmbeddedTestMiddle struct {
+	*EmbeddedTestInner
+	Count int
+}
+
+type embeddedTestHidden struct {
+	Hidden string
+}
+
+// EmbeddedTestService is a structure used in the tests.
+type EmbeddedTestService struct {
+	EmbeddedTestBase
+	*EmbeddedTestMiddle
+	embeddedTestHidden
+	Own string
+}
@@ -0,0 +1,10 @@
+package models
+
+// ErrorsTestA is a structure used in the tests.
+type ErrorsTestA struct {
+	P1 string
+	P2 *ErrorsTestB
+}
+
+// ErrorsTestB is a structure used in the tests.
+type ErrorsTestB struct{}
@@ -0,0 +1,29 @@
+package models
+
+// FactoryTestConfig is a structure used in the tests.
+type FactoryTestConfig struct {
+	URL string
+}
+
+// FactoryTestClient is a structure used in the tests.
+type FactoryTestClient struct {
+	Config   *FactoryTestConfig
+	TenantID string
+	Retries  int
+}
+
+// NewFactoryTestClient creates a FactoryTestClient.
+func NewFactoryTestClient(config *FactoryTestConfig, tenantID string, retries int) (*FactoryTestClient, error) {
+	return &FactoryTestClient{Config: config, TenantID: tenantID, Retries: retries}, nil
+}
+
+// FactoryTestSession is a structure used in the tests.
+type FactoryTestSession struct {
+	Config *FactoryTestConfig
+	UserID string
+}
+
+// FactoryTestUser is a structure used in the tests.
+type FactoryTestUser struct {
+	Client *FactoryTestClient
+}
@@ -0,0 +1,49 @@
+//go:build go1.18
+// +build go1.18
+
+package models
+
+import (
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/models/testinterfaces"
+)
+
+// GenericsTestItem is a structure used in the tests.
+type GenericsTestItem struct {
+	Value string
+}
+
+// GenericsTestStore is a generic structure used in the tests.
+type GenericsTestStore[T any] struct {
+	Item  T
+	Items []T
+}
+
+// GenericsTestPair is a generic structure used in the tests.
+type GenericsTestPair[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// NewGenericsTestPair is a generic function used in the tests.
+func NewGenericsTestPair[K comparable, V any](key K, value V) (*GenericsTestPair[K, V], error) {
+	return &GenericsTestPair[K, V]{Key: key, Value: value}, nil
+}
+
+// GenericsTestInterfacePair is a generic type with a type argument from another package.
+type GenericsTestInterfacePair = GenericsTestPair[string, testinterfaces.InterfacesTestInterface]
+
+// GenericsTestLazyStore is a structure used in the tests.
+type GenericsTestLazyStore struct {
+	Item dingo.Lazy[*GenericsTestItem]
+}
+
+// GenericsTestBox is a generic structure used in the tests.
+type GenericsTestBox[T any] struct {
+	Value T
+}
+
+// GenericsTestCount is used in the tests as a variadic type argument.
+func GenericsTestCount(items ...*GenericsTestItem) int {
+	return len(items)
+}
@@ -0,0 +1,56 @@
+package models
+
+// InterfaceAutofillTestLogger is an interface used in the tests.
+type InterfaceAutofillTestLogger interface {
+	Log(msg string) string
+}
+
+// InterfaceAutofillTestNotifier is an interface used in the tests.
+type InterfaceAutofillTestNotifier interface {
+	Notify(msg string) string
+}
+
+// InterfaceAutofillTestZapLogger is a structure used in the tests.
+type InterfaceAutofillTestZapLogger struct{}
+
+// Log implements InterfaceAutofillTestLogger.
+func (l *InterfaceAutofillTestZapLogger) Log(msg string) string { return "zap: " + msg }
+
+// InterfaceAutofillTestMailer is a structure used in the tests.
+// It implements both InterfaceAutofillTestLogger and InterfaceAutofillTestNotifier.
+type InterfaceAutofillTestMailer struct{}
+
+// Log implements InterfaceAutofillTestLogger.
+func (m *InterfaceAutofillTestMailer) Log(msg string) string { return "mailer: " + msg }
+
+// Notify implements InterfaceAutofillTestNotifier.
+func (m *InterfaceAutofillTestMailer) Notify(msg string) string { return "mail: " + msg }
+
+// InterfaceAutofillTestService is a structure used in the tests.
+type InterfaceAutofillTestService struct {
+	Logger   InterfaceAutofillTestLogger
+	Notifier InterfaceAutofillTestNotifier
+}
+
+// InterfaceAutofillTestStore is an interface used in the tests.
+type InterfaceAutofillTestStore interface {
+	Store(key string) string
+}
+
+// InterfaceAutofillTestMemoryStore is a structure used in the tests.
+type InterfaceAutofillTestMemoryStore struct{}
+
+// Store implements InterfaceAutofillTestStore.
+func (s *InterfaceAutofillTestMemoryStore) Store(key string) string { return "memory: " + key }
+
+// InterfaceAutofillTestFileStore is a structure used in the tests.
+type InterfaceAutofillTestFileStore struct{}
+
+// Store implements InterfaceAutofillTestStore.
+func (s *InterfaceAutofillTestFileStore) Store(key string) string { return "file: " + key }
+
+// InterfaceAutofillTestStoreUser is a structure used in the tests.
+// Two definitions implement the interface of its field.
+type InterfaceAutofillTestStoreUser struct {
+	Store InterfaceAutofillTestStore
+}
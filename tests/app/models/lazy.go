@@ -0,0 +1,51 @@
+package models
+
+import "sync/atomic"
+
+// LazyTestDBBuilds counts the LazyTestDB created by NewLazyTestDB.
+var LazyTestDBBuilds int32
+
+// LazyTestDB is a structure used in the tests.
+type LazyTestDB struct {
+	ID int32
+}
+
+// NewLazyTestDB creates a LazyTestDB and increments LazyTestDBBuilds.
+func NewLazyTestDB() *LazyTestDB {
+	return &LazyTestDB{ID: atomic.AddInt32(&LazyTestDBBuilds, 1)}
+}
+
+// LazyTestHandler is a structure used in the tests.
+type LazyTestHandler struct {
+	GetDB func() (*LazyTestDB, error)
+}
+
+// LazyTestReport is a structure used in the tests.
+type LazyTestReport struct {
+	GetDB func() (*LazyTestDB, error)
+}
+
+// NewLazyTestReport creates a LazyTestReport.
+func NewLazyTestReport(getDB func() (*LazyTestDB, error)) *LazyTestReport {
+	return &LazyTestReport{GetDB: getDB}
+}
+
+// LazyTestParent is a structure used in the tests.
+type LazyTestParent struct {
+	Child *LazyTestChild
+}
+
+// LazyTestChild is a structure used in the tests.
+// It depends on its parent lazily to avoid a dependency cycle.
+type LazyTestChild struct {
+	GetParent func() (*LazyTestParent, error)
+}
+
+// LazyTestCache is a structure used in the tests.
+type LazyTestCache struct{}
+
+// LazyTestCacheUser is a structure used in the tests.
+// Two definitions have the type of its lazy field.
+type LazyTestCacheUser struct {
+	GetCache func() (*LazyTestCache, error)
+}
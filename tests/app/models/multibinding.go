@@ -0,0 +1,39 @@
+package models
+
+// MultiBindingTestHandler is an interface used in the tests.
+type MultiBindingTestHandler interface {
+	Handle() string
+}
+
+// MultiBindingTestHandlerA is a structure used in the tests.
+type MultiBindingTestHandlerA struct{}
+
+// Handle implements MultiBindingTestHandler.
+func (h *MultiBindingTestHandlerA) Handle() string { return "A" }
+
+// MultiBindingTestHandlerB is a structure used in the tests.
+type MultiBindingTestHandlerB struct{}
+
+// Handle implements MultiBindingTestHandler.
+func (h *MultiBindingTestHandlerB) Handle() string { return "B" }
+
+// MultiBindingTestHandlerC is a structure used in the tests.
+type MultiBindingTestHandlerC struct{}
+
+// Handle implements MultiBindingTestHandler.
+func (h *MultiBindingTestHandlerC) Handle() string { return "C" }
+
+// MultiBindingTestDispatcher is a structure used in the tests.
+type MultiBindingTestDispatcher struct {
+	Handlers []MultiBindingTestHandler
+}
+
+// Handle implements MultiBindingTestHandler.
+// The dispatcher should not be injected in itself.
+func (d *MultiBindingTestDispatcher) Handle() string {
+	str := ""
+	for _, h := range d.Handlers {
+		str += h.Handle()
+	}
+	return str
+}
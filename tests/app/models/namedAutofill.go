@@ -0,0 +1,29 @@
+package models
+
+// NamedAutofillTestDB is a structure used in the tests.
+type NamedAutofillTestDB struct {
+	Name string
+}
+
+// NamedAutofillTestRepository is a structure used in the tests.
+type NamedAutofillTestRepository struct {
+	TestNamedAutofillPrimaryDB *NamedAutofillTestDB
+	TestNamedAutofillReplicaDB *NamedAutofillTestDB
+	Reports                    *NamedAutofillTestDB `dingo:"test_named_autofill_replica_db"`
+}
+
+// NamedAutofillTestCache is a structure used in the tests.
+type NamedAutofillTestCache struct {
+	Name string
+}
+
+// NamedAutofillTestCacheUser is a structure used in the tests.
+// The name of its dingo tag is misspelled.
+type NamedAutofillTestCacheUser struct {
+	Cache *NamedAutofillTestCache `dingo:"test_invalid_named_autofill_cahce"`
+}
+
+// NamedAutofillTestTaggedRepository is a structure used in the tests.
+type NamedAutofillTestTaggedRepository struct {
+	DB *NamedAutofillTestDB `dingo:"test_named_autofill_unknown_db"`
+}
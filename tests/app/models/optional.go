@@ -0,0 +1,19 @@
+package models
+
+// OptionalTestCache is a structure used in the tests.
+type OptionalTestCache struct{}
+
+// OptionalTestMetrics is a structure used in the tests.
+// There is no definition with this type.
+type OptionalTestMetrics struct{}
+
+// OptionalTestService is a structure used in the tests.
+type OptionalTestService struct {
+	Cache   *OptionalTestCache
+	Metrics *OptionalTestMetrics
+}
+
+// NewOptionalTestService creates an OptionalTestService.
+func NewOptionalTestService(cache *OptionalTestCache, metrics *OptionalTestMetrics) (*OptionalTestService, error) {
+	return &OptionalTestService{Cache: cache, Metrics: metrics}, nil
+}
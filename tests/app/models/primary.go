@@ -0,0 +1,29 @@
+package models
+
+// PrimaryTestDB is a structure used in the tests.
+type PrimaryTestDB struct {
+	Name string
+}
+
+// PrimaryTestCache is an interface used in the tests.
+type PrimaryTestCache interface {
+	CacheName() string
+}
+
+// PrimaryTestMemoryCache is a structure used in the tests.
+type PrimaryTestMemoryCache struct{}
+
+// CacheName implements PrimaryTestCache.
+func (c *PrimaryTestMemoryCache) CacheName() string { return "memory" }
+
+// PrimaryTestRedisCache is a structure used in the tests.
+type PrimaryTestRedisCache struct{}
+
+// CacheName implements PrimaryTestCache.
+func (c *PrimaryTestRedisCache) CacheName() string { return "redis" }
+
+// PrimaryTestRepository is a structure used in the tests.
+type PrimaryTestRepository struct {
+	DB    *PrimaryTestDB
+	Cache PrimaryTestCache
+}
@@ -0,0 +1,49 @@
+package models
+
+// ReturnShapesTestConn is a structure used in the tests.
+type ReturnShapesTestConn struct {
+	Closed bool
+}
+
+// ReturnShapesTestValue is a structure used in the tests.
+// It is not comparable.
+type ReturnShapesTestValue struct {
+	Values []string
+}
+
+// ReturnShapesTestEmpty is a structure used in the tests.
+// Its pointers can share the same address.
+type ReturnShapesTestEmpty struct{}
+
+// ReturnShapesTestError is an error type used in the tests.
+type ReturnShapesTestError struct {
+	Message string
+}
+
+// Error returns the message of the error.
+func (e *ReturnShapesTestError) Error() string {
+	return e.Message
+}
+
+// ReturnShapesTestStringer is a structure used in the tests.
+type ReturnShapesTestStringer struct {
+	Closed bool
+}
+
+// String implements fmt.Stringer.
+func (s *ReturnShapesTestStringer) String() string {
+	return "stringer"
+}
+
+// ReturnShapesTestNameCleanups counts the cleanup functions
+// called for the ReturnShapesTestName objects.
+var ReturnShapesTestNameCleanups int32
+
+// ReturnShapesTestName is a type used in the tests.
+// Its values are not pointers.
+type ReturnShapesTestName string
+
+// String implements fmt.Stringer.
+func (n ReturnShapesTestName) String() string {
+	return string(n)
+}
@@ -0,0 +1,23 @@
+package models
+
+// StructTagsTestLogger is a structure used in the tests.
+type StructTagsTestLogger struct{}
+
+// StructTagsTestCounter is a structure used in the tests.
+type StructTagsTestCounter struct{}
+
+// StructTagsTestService is a structure used in the tests.
+type StructTagsTestService struct {
+	Logger     *StructTagsTestLogger `dingo:"service=test_struct_tags_logger"`
+	Missing    *StructTagsTestLogger `dingo:"service=test_struct_tags_missing,optional"`
+	Overridden *StructTagsTestLogger `dingo:"-"`
+	Counter    *StructTagsTestCounter
+	Skipped    *StructTagsTestCounter `dingo:"-"`
+	NoAutoFill *StructTagsTestCounter `dingo:"autofill=false"`
+}
+
+// StructTagsTestInvalidService is a structure used in the tests.
+type StructTagsTestInvalidService struct {
+	Logger  *StructTagsTestLogger  `dingo:"service=test_struct_tags_missing"`
+	Counter *StructTagsTestCounter `dingo:"lazy=true"`
+}
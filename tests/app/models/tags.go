@@ -0,0 +1,23 @@
+package models
+
+// TagsTestListener is an interface used in the tests.
+type TagsTestListener interface {
+	Listen() string
+}
+
+// TagsTestListenerA is a structure used in the tests.
+type TagsTestListenerA struct{}
+
+// Listen implements TagsTestListener.
+func (l *TagsTestListenerA) Listen() string { return "A" }
+
+// TagsTestListenerB is a structure used in the tests.
+type TagsTestListenerB struct{}
+
+// Listen implements TagsTestListener.
+func (l *TagsTestListenerB) Listen() string { return "B" }
+
+// TagsTestBus is a structure used in the tests.
+type TagsTestBus struct {
+	Listeners []TagsTestListener
+}
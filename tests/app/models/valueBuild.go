@@ -0,0 +1,22 @@
+package models
+
+// ValueBuildTestLogger is a structure used in the tests.
+type ValueBuildTestLogger struct{}
+
+// ValueBuildTestConfig is a structure used in the tests.
+type ValueBuildTestConfig struct {
+	Host   string
+	Logger *ValueBuildTestLogger
+}
+
+// ValueBuildTestHeaders is a named map used in the tests.
+type ValueBuildTestHeaders map[string]string
+
+// ValueBuildTestNames is a named slice used in the tests.
+type ValueBuildTestNames []string
+
+// ValueBuildTestHandler is a named function type used in the tests.
+type ValueBuildTestHandler func() string
+
+// ValueBuildTestPort is a named integer used in the tests.
+type ValueBuildTestPort int
@@ -0,0 +1,30 @@
+package models
+
+// VariadicTestOption is a structure used in the tests.
+type VariadicTestOption struct {
+	Name string
+}
+
+// VariadicTestUnused is a structure used in the tests.
+// There is no definition with this type.
+type VariadicTestUnused struct{}
+
+// VariadicTestServer is a structure used in the tests.
+type VariadicTestServer struct {
+	Addr    string
+	Options []string
+}
+
+// NewVariadicTestServer creates a VariadicTestServer.
+func NewVariadicTestServer(addr string, opts ...*VariadicTestOption) (*VariadicTestServer, error) {
+	s := &VariadicTestServer{Addr: addr}
+	for _, opt := range opts {
+		s.Options = append(s.Options, opt.Name)
+	}
+	return s, nil
+}
+
+// NewVariadicTestUnusedServer creates a VariadicTestServer.
+func NewVariadicTestUnusedServer(addr string, unused ...*VariadicTestUnused) (*VariadicTestServer, error) {
+	return &VariadicTestServer{Addr: addr, Options: make([]string, len(unused))}, nil
+}
@@ -0,0 +1,52 @@
+package services
+
+import (
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/models"
+)
+
+// AliasDecls is used in the tests.
+var AliasDecls = []dingo.Def{
+	{
+		Name:  "test_alias_service",
+		Build: (*models.AliasTestService)(nil),
+		Params: dingo.Params{
+			"Value": "value",
+		},
+		Aliases: []string{"test_alias_old_service", "test_alias_legacy_service"},
+	},
+	{
+		Name:  "test_alias_consumer",
+		Build: (*models.AliasTestConsumer)(nil),
+		Params: dingo.Params{
+			"Service": dingo.Service("test_alias_old_service"),
+		},
+	},
+}
+
+// InvalidAliasDecls is used in the tests.
+var InvalidAliasDecls = []dingo.Def{
+	{
+		Name:    "test_alias_1",
+		Build:   (*models.AliasTestService)(nil),
+		Aliases: []string{"test_alias_2"},
+	},
+	{
+		Name:    "test_alias_2",
+		Build:   (*models.AliasTestService)(nil),
+		Aliases: []string{"test-alias-1"},
+	},
+	{
+		Name:    "test_alias_3",
+		Build:   (*models.AliasTestService)(nil),
+		Aliases: []string{"test_alias_old", "test_alias_old"},
+	},
+	{
+		Name:  "test_alias_4",
+		Build: (*models.AliasTestService)(nil),
+		Params: dingo.Params{
+			"Value": dingo.Arg("value"),
+		},
+		Aliases: []string{"test_alias_new"},
+	},
+}
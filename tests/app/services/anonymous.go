@@ -0,0 +1,40 @@
+package services
+
+import (
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/models"
+)
+
+// AnonymousDecls is used in the tests.
+var AnonymousDecls = []dingo.Def{
+	{
+		Name: "test_anonymous_1",
+		Build: func() (interface{ Close() error }, error) {
+			return &models.AnonymousTestCloser{}, nil
+		},
+	},
+	{
+		Name: "test_anonymous_2",
+		Build: func(cfg struct {
+			Host string `json:"host"`
+			models.AnonymousTestEmbedded
+		}) (*models.AnonymousTestConfig, error) {
+			return &models.AnonymousTestConfig{Host: cfg.Host, Port: cfg.Port}, nil
+		},
+		Params: dingo.NewFuncParams(struct {
+			Host string `json:"host"`
+			models.AnonymousTestEmbedded
+		}{
+			Host:                  "localhost",
+			AnonymousTestEmbedded: models.AnonymousTestEmbedded{Port: 8080},
+		}),
+	},
+	{
+		Name:  "test_anonymous_3",
+		Build: (*models.AnonymousTestConfig)(nil),
+		Params: dingo.Params{
+			"Host":   "localhost",
+			"Logger": &models.AnonymousTestLogger{},
+		},
+	},
+}
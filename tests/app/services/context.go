@@ -0,0 +1,73 @@
+package services
+
+import (
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/models"
+)
+
+// ContextDecls is used in the tests.
+var ContextDecls = []dingo.Def{
+	{
+		Name:   "test_context_1",
+		Build:  models.NewContextTestClient,
+		Params: dingo.Params{"1": "name"},
+	},
+	{
+		Name:     "test_context_2",
+		Build:    models.NewContextTestClient,
+		Params:   dingo.NewFuncParams(dingo.Context, "name"),
+		Unshared: true,
+	},
+	{
+		Name:  "test_context_3",
+		Build: (*models.ContextTestStruct)(nil),
+		Params: dingo.Params{
+			"Ctx": dingo.Context,
+		},
+	},
+	{
+		Name:   "test_context_4",
+		Build:  models.NewContextTestService,
+		Params: dingo.Params{"1": dingo.Service("test_context_5")},
+	},
+	{
+		Name:   "test_context_5",
+		Build:  models.NewContextTestClient,
+		Params: dingo.Params{"1": "name"},
+	},
+	{
+		Name:  "test_context_6",
+		Build: (*models.ContextTestWrapper)(nil),
+		Params: dingo.Params{
+			"Client": dingo.Service("test_context_7"),
+		},
+	},
+	{
+		Name:   "test_context_7",
+		Build:  models.NewContextTestClient,
+		Params: dingo.Params{"1": "name"},
+	},
+	{
+		Name:   "test_context_8",
+		Build:  models.NewContextTestClient,
+		Params: dingo.Params{"1": "name"},
+		Close: func(client *models.ContextTestClient) error {
+			client.Closed = true
+			return nil
+		},
+		Unshared: true,
+	},
+}
+
+// ContextCollisionDecls is used in the tests.
+var ContextCollisionDecls = []dingo.Def{
+	{
+		Name:   "test_context_collision",
+		Build:  models.NewContextTestClient,
+		Params: dingo.Params{"1": "name"},
+	},
+	{
+		Name:  "test_context_collision_ctx",
+		Build: (*models.ContextTestClient)(nil),
+	},
+}
@@ -0,0 +1,26 @@
+package services
+
+import (
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/models"
+)
+
+// CycleDecls is used in the tests.
+// The definitions contain a dependency cycle,
+// so they are not included in the main Provider.
+var CycleDecls = []dingo.Def{
+	{
+		Name:  "test_cycle_1",
+		Build: (*models.CycleTestA)(nil),
+	},
+	{
+		Name: "test_cycle_2",
+		Build: func(c *models.CycleTestC) (*models.CycleTestB, error) {
+			return &models.CycleTestB{C: c}, nil
+		},
+	},
+	{
+		Name:  "test_cycle_3",
+		Build: (*models.CycleTestC)(nil),
+	},
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/models"
+)
+
+// DecoratorDecls is used in the tests.
+var DecoratorDecls = []dingo.Def{
+	{
+		Name:  "test_decorator_store",
+		Build: models.NewDecoratorTestStore,
+	},
+	{
+		Name:      "test_decorator_metrics",
+		Build:     models.NewDecoratorTestWrapper,
+		Params:    dingo.NewFuncParams(dingo.Decorated, "metrics"),
+		Decorates: "test_decorator_store",
+		Order:     2,
+	},
+	{
+		Name:  "test_decorator_logging",
+		Build: (*models.DecoratorTestWrapper)(nil),
+		Params: dingo.Params{
+			"Name": "logging",
+		},
+		Decorates: "test_decorator_store",
+		Order:     1,
+	},
+	{
+		Name:  "test_decorator_consumer",
+		Build: (*models.DecoratorTestConsumer)(nil),
+	},
+}
+
+// InvalidDecoratorDecls is used in the tests.
+var InvalidDecoratorDecls = []dingo.Def{
+	{
+		Name:  "test_decorator_store",
+		Build: models.NewDecoratorTestStore,
+	},
+	{
+		Name:  "test_decorator_logging",
+		Build: (*models.DecoratorTestWrapper)(nil),
+		Params: dingo.Params{
+			"Name": "logging",
+		},
+		Decorates: "test_decorator_store",
+	},
+	{
+		Name:      "test_decorator_invalid_1",
+		Build:     (*models.DecoratorTestWrapper)(nil),
+		Decorates: "test_decorator_logging",
+	},
+	{
+		Name:      "test_decorator_invalid_2",
+		Build:     (*models.DecoratorTestWrapper)(nil),
+		Decorates: "test_decorator_unknown",
+	},
+	{
+		Name:      "test_decorator_invalid_3",
+		Build:     (*models.DecoratorTestConsumer)(nil),
+		Decorates: "test_decorator_store",
+	},
+	{
+		Name:      "test_decorator_invalid_4",
+		Build:     models.NewDecoratorTestStore,
+		Decorates: "test_decorator_store",
+	},
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/models"
+)
+
+// EmbeddedDecls is used in the tests.
+var EmbeddedDecls = []dingo.Def{
+	{
+		Name:  "test_embedded_logger",
+		Build: (*models.EmbeddedTestLogger)(nil),
+	},
+	{
+		Name:  "test_embedded_1",
+		Build: (*models.EmbeddedTestService)(nil),
+		Params: dingo.Params{
+			"EmbeddedTestBase.Name":                      "base",
+			"EmbeddedTestMiddle.EmbeddedTestInner.Value": "inner",
+			"EmbeddedTestMiddle.Count":                   3,
+			"Own":                                        "own",
+		},
+	},
+	{
+		Name:  "test_embedded_2",
+		Build: (*models.EmbeddedTestService)(nil),
+		Params: dingo.Params{
+			"EmbeddedTestBase":   models.EmbeddedTestBase{Name: "whole"},
+			"EmbeddedTestMiddle": dingo.AutoFill(false),
+		},
+	},
+}
+
+// InvalidEmbeddedDecls is used in the tests.
+var InvalidEmbeddedDecls = []dingo.Def{
+	{
+		Name:  "test_invalid_embedded_1",
+		Build: (*models.EmbeddedTestService)(nil),
+		Params: dingo.Params{
+			"embeddedTestHidden.Hidden": "hidden",
+		},
+	},
+}
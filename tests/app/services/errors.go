@@ -0,0 +1,44 @@
+package services
+
+import (
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/models"
+)
+
+// ErrorsDecls is used in the tests.
+// The definitions contain several errors,
+// so they are not included in the main Provider.
+var ErrorsDecls = []dingo.Def{
+	{
+		Name:  "test_errors_1",
+		Build: (*models.ErrorsTestA)(nil),
+		Params: dingo.Params{
+			"P1": 1,
+			"P2": dingo.Service("undefined"),
+			"P3": "unknown",
+		},
+	},
+	dingo.Declare(dingo.Def{
+		Name:  "test_errors_2",
+		Build: "invalid",
+	}),
+	{
+		Name:  "test_errors_3",
+		Build: (*models.ErrorsTestB)(nil),
+		Close: func(b *models.ErrorsTestA) error {
+			return nil
+		},
+	},
+	{
+		Name: "test_errors_4",
+		Build: func(b *models.ErrorsTestB) (*models.ErrorsTestB, error) {
+			return b, nil
+		},
+	},
+	{
+		Name: "test_errors_5",
+		Build: func() (*models.ErrorsTestB, error) {
+			return &models.ErrorsTestB{}, nil
+		},
+	},
+}
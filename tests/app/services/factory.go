@@ -0,0 +1,84 @@
+package services
+
+import (
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/models"
+)
+
+// FactoryDecls is used in the tests.
+var FactoryDecls = []dingo.Def{
+	{
+		Name:  "test_factory_config",
+		Build: (*models.FactoryTestConfig)(nil),
+		Params: dingo.Params{
+			"URL": "url",
+		},
+	},
+	{
+		Name:  "test_factory_client",
+		Build: models.NewFactoryTestClient,
+		Params: dingo.Params{
+			"1": dingo.Arg("tenantID"),
+			"2": dingo.Arg("retries"),
+		},
+	},
+	{
+		Name:  "test_factory_session",
+		Build: (*models.FactoryTestSession)(nil),
+		Params: dingo.Params{
+			"UserID": dingo.Arg("userID"),
+		},
+	},
+	{
+		Name:  "test_factory_user",
+		Build: (*models.FactoryTestUser)(nil),
+	},
+}
+
+// InvalidFactoryDecls is used in the tests.
+var InvalidFactoryDecls = []dingo.Def{
+	{
+		Name:  "test_factory_config",
+		Build: (*models.FactoryTestConfig)(nil),
+	},
+	{
+		Name:  "test_factory_client",
+		Build: models.NewFactoryTestClient,
+		Params: dingo.Params{
+			"1": dingo.Arg("tenantID"),
+			"2": dingo.Arg("retries"),
+		},
+		Close: func(*models.FactoryTestClient) error { return nil },
+	},
+	{
+		Name:  "test_factory_client_2",
+		Build: models.NewFactoryTestClient,
+		Params: dingo.Params{
+			"1": dingo.Arg("err"),
+			"2": dingo.Arg("retries"),
+		},
+	},
+	{
+		Name:  "test_factory_client_3",
+		Build: models.NewFactoryTestClient,
+		Params: dingo.Params{
+			"1": dingo.Arg("tenant-id"),
+			"2": dingo.Arg("retries"),
+		},
+	},
+	{
+		Name:  "test_factory_session",
+		Build: (*models.FactoryTestSession)(nil),
+		Params: dingo.Params{
+			"Config": dingo.Arg("value"),
+			"UserID": dingo.Arg("value"),
+		},
+	},
+	{
+		Name:  "test_factory_user",
+		Build: (*models.FactoryTestUser)(nil),
+		Params: dingo.Params{
+			"Client": dingo.Service("test_factory_client_2"),
+		},
+	},
+}
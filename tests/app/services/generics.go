@@ -0,0 +1,51 @@
+//go:build go1.18
+// +build go1.18
+
+package services
+
+import (
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/models"
+	"github.com/sarulabs/dingo/v4/tests/app/models/testinterfaces"
+)
+
+// GenericsDecls is used in the tests.
+var GenericsDecls = []dingo.Def{
+	{
+		Name:  "test_generics_1",
+		Build: (*models.GenericsTestItem)(nil),
+		Params: dingo.Params{
+			"Value": "item",
+		},
+	},
+	{
+		Name:  "test_generics_2",
+		Build: (*models.GenericsTestStore[*models.GenericsTestItem])(nil),
+	},
+	{
+		Name:  "test_generics_3",
+		Build: models.NewGenericsTestPair[string, *models.GenericsTestStore[*models.GenericsTestItem]],
+		Params: dingo.Params{
+			"0": "key",
+		},
+	},
+	{
+		Name:  "test_generics_4",
+		Build: models.NewGenericsTestPair[string, testinterfaces.InterfacesTestInterface],
+		Params: dingo.Params{
+			"0": "key",
+			"1": models.InterfacesTestA{Value: "value"},
+		},
+	},
+	{
+		Name:  "test_generics_5",
+		Build: (*models.GenericsTestLazyStore)(nil),
+	},
+	{
+		Name:  "test_generics_6",
+		Build: (*models.GenericsTestBox[func(...*models.GenericsTestItem) int])(nil),
+		Params: dingo.Params{
+			"Value": models.GenericsTestCount,
+		},
+	},
+}
@@ -0,0 +1,10 @@
+//go:build !go1.18
+// +build !go1.18
+
+package services
+
+import "github.com/sarulabs/dingo/v4"
+
+// GenericsDecls is used in the tests.
+// Generics are only available since go1.18.
+var GenericsDecls = []dingo.Def{}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/models"
+)
+
+// InterfaceAutofillDecls is used in the tests.
+var InterfaceAutofillDecls = []dingo.Def{
+	{
+		Name:  "test_interface_autofill_logger",
+		Build: (*models.InterfaceAutofillTestZapLogger)(nil),
+	},
+	{
+		Name:  "test_interface_autofill_mailer",
+		Build: (*models.InterfaceAutofillTestMailer)(nil),
+		// The mailer is also a logger, but it should not be used as one.
+		As: []interface{}{(*models.InterfaceAutofillTestNotifier)(nil)},
+	},
+	{
+		Name:  "test_interface_autofill_1",
+		Build: (*models.InterfaceAutofillTestService)(nil),
+	},
+	{
+		Name: "test_interface_autofill_2",
+		Build: func(l models.InterfaceAutofillTestLogger) (*models.InterfaceAutofillTestService, error) {
+			return &models.InterfaceAutofillTestService{Logger: l}, nil
+		},
+	},
+	{
+		Name:  "test_interface_autofill_memory_store",
+		Build: (*models.InterfaceAutofillTestMemoryStore)(nil),
+	},
+	{
+		Name:  "test_interface_autofill_file_store",
+		Build: (*models.InterfaceAutofillTestFileStore)(nil),
+	},
+	{
+		// The field is ambiguous, so it is not filled.
+		Name:  "test_interface_autofill_3",
+		Build: (*models.InterfaceAutofillTestStoreUser)(nil),
+	},
+}
+
+// AmbiguousInterfaceAutofillDecls is used in the tests.
+var AmbiguousInterfaceAutofillDecls = []dingo.Def{
+	{
+		Name:  "test_ambiguous_interface_autofill_logger",
+		Build: (*models.InterfaceAutofillTestZapLogger)(nil),
+	},
+	{
+		Name:  "test_ambiguous_interface_autofill_mailer",
+		Build: (*models.InterfaceAutofillTestMailer)(nil),
+	},
+	{
+		Name: "test_ambiguous_interface_autofill_1",
+		Build: func(l models.InterfaceAutofillTestLogger) (*models.InterfaceAutofillTestService, error) {
+			return &models.InterfaceAutofillTestService{Logger: l}, nil
+		},
+	},
+	{
+		Name:  "test_ambiguous_interface_autofill_2",
+		Build: (*models.InterfaceAutofillTestZapLogger)(nil),
+		As:    []interface{}{(*models.InterfaceAutofillTestNotifier)(nil)},
+	},
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/models"
+)
+
+// LazyDecls is used in the tests.
+var LazyDecls = []dingo.Def{
+	{
+		Name:     "test_lazy_db",
+		Build:    models.NewLazyTestDB,
+		Unshared: true,
+	},
+	{
+		Name:  "test_lazy_handler",
+		Build: (*models.LazyTestHandler)(nil),
+	},
+	{
+		Name:   "test_lazy_report",
+		Build:  models.NewLazyTestReport,
+		Params: dingo.NewFuncParams(dingo.LazyService("test_lazy_db")),
+	},
+	{
+		Name:  "test_lazy_parent",
+		Build: (*models.LazyTestParent)(nil),
+	},
+	{
+		Name:  "test_lazy_child",
+		Build: (*models.LazyTestChild)(nil),
+	},
+	{
+		Name:  "test_lazy_cache_1",
+		Build: (*models.LazyTestCache)(nil),
+	},
+	{
+		Name:  "test_lazy_cache_2",
+		Build: (*models.LazyTestCache)(nil),
+	},
+	{
+		// The field is ambiguous, so it is not filled.
+		Name:  "test_lazy_cache_user",
+		Build: (*models.LazyTestCacheUser)(nil),
+	},
+}
+
+// InvalidLazyDecls is used in the tests.
+var InvalidLazyDecls = []dingo.Def{
+	{
+		Name:  "test_lazy_db",
+		Build: (*models.LazyTestDB)(nil),
+	},
+	{
+		Name:  "test_lazy_parent",
+		Build: (*models.LazyTestParent)(nil),
+	},
+	{
+		Name:   "test_lazy_report",
+		Build:  models.NewLazyTestReport,
+		Params: dingo.NewFuncParams(dingo.LazyService("test_lazy_parent")),
+	},
+	{
+		Name:  "test_lazy_child",
+		Build: (*models.LazyTestChild)(nil),
+		Params: dingo.Params{
+			"GetParent": dingo.LazyService("test_lazy_db"),
+		},
+	},
+	{
+		Name:  "test_lazy_parent_2",
+		Build: (*models.LazyTestParent)(nil),
+		Params: dingo.Params{
+			"Child": dingo.LazyService("test_lazy_db"),
+		},
+	},
+}
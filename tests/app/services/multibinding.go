@@ -0,0 +1,46 @@
+package services
+
+import (
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/models"
+)
+
+// MultiBindingDecls is used in the tests.
+var MultiBindingDecls = []dingo.Def{
+	{
+		Name:  "test_multibinding_handler_a",
+		Build: (*models.MultiBindingTestHandlerA)(nil),
+		Order: 2,
+	},
+	{
+		Name:  "test_multibinding_handler_b",
+		Build: (*models.MultiBindingTestHandlerB)(nil),
+		Order: 1,
+	},
+	{
+		Name:           "test_multibinding_handler_c",
+		Build:          (*models.MultiBindingTestHandlerC)(nil),
+		NotForAutoFill: true,
+	},
+	{
+		Name:           "test_multibinding_1",
+		Build:          (*models.MultiBindingTestDispatcher)(nil),
+		NotForAutoFill: true,
+	},
+	{
+		Name: "test_multibinding_2",
+		Build: func(handlers []models.MultiBindingTestHandler) (*models.MultiBindingTestDispatcher, error) {
+			return &models.MultiBindingTestDispatcher{Handlers: handlers}, nil
+		},
+		Params:         dingo.NewFuncParams(dingo.All()),
+		NotForAutoFill: true,
+	},
+	{
+		Name: "test_multibinding_3",
+		Build: func(handlers []*models.MultiBindingTestHandlerA) (int, error) {
+			return len(handlers), nil
+		},
+		Params:         dingo.NewFuncParams(dingo.All()),
+		NotForAutoFill: true,
+	},
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/models"
+)
+
+// NamedAutofillDecls is used in the tests.
+var NamedAutofillDecls = []dingo.Def{
+	{
+		Name: "test_named_autofill_primary_db",
+		Build: func() (*models.NamedAutofillTestDB, error) {
+			return &models.NamedAutofillTestDB{Name: "primary"}, nil
+		},
+	},
+	{
+		Name: "test_named_autofill_replica_db",
+		Build: func() (*models.NamedAutofillTestDB, error) {
+			return &models.NamedAutofillTestDB{Name: "replica"}, nil
+		},
+	},
+	{
+		Name:           "test_named_autofill_1",
+		Build:          (*models.NamedAutofillTestRepository)(nil),
+		AutoFillByName: true,
+	},
+}
+
+// InvalidNamedAutofillDecls is used in the tests.
+var InvalidNamedAutofillDecls = []dingo.Def{
+	{
+		Name:  "test_invalid_named_autofill_primary_db",
+		Build: (*models.NamedAutofillTestDB)(nil),
+	},
+	{
+		Name:  "test_invalid_named_autofill_replica_db",
+		Build: (*models.NamedAutofillTestDB)(nil),
+	},
+	{
+		Name:  "test_invalid_named_autofill_1",
+		Build: (*models.NamedAutofillTestTaggedRepository)(nil),
+	},
+	{
+		// AutoFillByName is not set, so the field names are not used.
+		Name: "test_invalid_named_autofill_2",
+		Build: (*struct {
+			TestInvalidNamedAutofillPrimaryDB *models.NamedAutofillTestDB
+		})(nil),
+	},
+	{
+		Name:  "test_invalid_named_autofill_cache",
+		Build: (*models.NamedAutofillTestCache)(nil),
+	},
+	{
+		// The tag is not ignored even if there is only one definition with this type.
+		Name:  "test_invalid_named_autofill_3",
+		Build: (*models.NamedAutofillTestCacheUser)(nil),
+	},
+}
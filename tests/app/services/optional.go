@@ -0,0 +1,30 @@
+package services
+
+import (
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/models"
+)
+
+// OptionalDecls is used in the tests.
+var OptionalDecls = []dingo.Def{
+	{
+		Name:  "test_optional_cache",
+		Build: (*models.OptionalTestCache)(nil),
+	},
+	{
+		Name:  "test_optional_1",
+		Build: models.NewOptionalTestService,
+		Params: dingo.NewFuncParams(
+			dingo.OptionalService("test_optional_cache"),
+			dingo.OptionalService("test_optional_metrics"),
+		),
+	},
+	{
+		Name:  "test_optional_2",
+		Build: models.NewOptionalTestService,
+		Params: dingo.NewFuncParams(
+			dingo.Optional(),
+			dingo.Optional(),
+		),
+	},
+}
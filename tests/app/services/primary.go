@@ -0,0 +1,70 @@
+package services
+
+import (
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/models"
+)
+
+// PrimaryDecls is used in the tests.
+var PrimaryDecls = []dingo.Def{
+	{
+		Name: "test_primary_db_main",
+		Build: func() (*models.PrimaryTestDB, error) {
+			return &models.PrimaryTestDB{Name: "main"}, nil
+		},
+		Primary: true,
+	},
+	{
+		Name: "test_primary_db_replica",
+		Build: func() (*models.PrimaryTestDB, error) {
+			return &models.PrimaryTestDB{Name: "replica"}, nil
+		},
+	},
+	{
+		Name:  "test_primary_cache_memory",
+		Build: (*models.PrimaryTestMemoryCache)(nil),
+	},
+	{
+		Name:    "test_primary_cache_redis",
+		Build:   (*models.PrimaryTestRedisCache)(nil),
+		Primary: true,
+	},
+	{
+		Name:  "test_primary_1",
+		Build: (*models.PrimaryTestRepository)(nil),
+	},
+}
+
+// InvalidPrimaryDecls is used in the tests.
+var InvalidPrimaryDecls = []dingo.Def{
+	{
+		Name:    "test_invalid_primary_cache_memory",
+		Build:   (*models.PrimaryTestMemoryCache)(nil),
+		Primary: true,
+	},
+	{
+		Name:    "test_invalid_primary_cache_redis",
+		Build:   (*models.PrimaryTestRedisCache)(nil),
+		Primary: true,
+	},
+	{
+		Name:    "test_invalid_primary_db_1",
+		Build:   (*models.PrimaryTestDB)(nil),
+		Primary: true,
+	},
+	{
+		Name:    "test_invalid_primary_db_2",
+		Build:   (*models.PrimaryTestDB)(nil),
+		Primary: true,
+	},
+	{
+		Name:           "test_invalid_primary_db_3",
+		Build:          (*models.PrimaryTestDB)(nil),
+		Primary:        true,
+		NotForAutoFill: true,
+	},
+	{
+		Name:  "test_invalid_primary_repository",
+		Build: (*models.PrimaryTestRepository)(nil),
+	},
+}
@@ -1,6 +1,8 @@
 package provider
 
 import (
+	"sync/atomic"
+
 	"github.com/sarulabs/dingo/v4"
 	"github.com/sarulabs/dingo/v4/tests/app/services"
 )
@@ -10,8 +12,13 @@ type Provider struct {
 	dingo.BaseProvider
 }
 
+// LoadCount is the number of calls to Load. It is used in the tests.
+var LoadCount int32
+
 // Load adds the definitions in the provider.
 func (p *Provider) Load() error {
+	atomic.AddInt32(&LoadCount, 1)
+
 	if err := p.Add(services.StructDecl); err != nil {
 		return err
 	}
@@ -63,5 +70,59 @@ func (p *Provider) Load() error {
 	if err := p.AddDefSlice(services.UnsharedDecls); err != nil {
 		return err
 	}
+	if err := p.AddDefSlice(services.GenericsDecls); err != nil {
+		return err
+	}
+	if err := p.AddDefSlice(services.AnonymousDecls); err != nil {
+		return err
+	}
+	if err := p.AddDefSlice(services.MultiBindingDecls); err != nil {
+		return err
+	}
+	if err := p.AddDefSlice(services.TagsDecls); err != nil {
+		return err
+	}
+	if err := p.AddDefSlice(services.InterfaceAutofillDecls); err != nil {
+		return err
+	}
+	if err := p.AddDefSlice(services.PrimaryDecls); err != nil {
+		return err
+	}
+	if err := p.AddDefSlice(services.NamedAutofillDecls); err != nil {
+		return err
+	}
+	if err := p.AddDefSlice(services.StructTagsDecls); err != nil {
+		return err
+	}
+	if err := p.AddDefSlice(services.EmbeddedDecls); err != nil {
+		return err
+	}
+	if err := p.AddDefSlice(services.ValueBuildDecls); err != nil {
+		return err
+	}
+	if err := p.AddDefSlice(services.VariadicDecls); err != nil {
+		return err
+	}
+	if err := p.AddDefSlice(services.ReturnShapesDecls); err != nil {
+		return err
+	}
+	if err := p.AddDefSlice(services.ContextDecls); err != nil {
+		return err
+	}
+	if err := p.AddDefSlice(services.OptionalDecls); err != nil {
+		return err
+	}
+	if err := p.AddDefSlice(services.LazyDecls); err != nil {
+		return err
+	}
+	if err := p.AddDefSlice(services.FactoryDecls); err != nil {
+		return err
+	}
+	if err := p.AddDefSlice(services.DecoratorDecls); err != nil {
+		return err
+	}
+	if err := p.AddDefSlice(services.AliasDecls); err != nil {
+		return err
+	}
 	return nil
 }
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/models"
+)
+
+// ReturnShapesDecls is used in the tests.
+var ReturnShapesDecls = []dingo.Def{
+	{
+		Name: "test_return_shapes_1",
+		Build: func() *models.ReturnShapesTestConn {
+			return &models.ReturnShapesTestConn{}
+		},
+	},
+	{
+		Name: "test_return_shapes_2",
+		Build: func() (*models.ReturnShapesTestConn, func(), error) {
+			conn := &models.ReturnShapesTestConn{}
+			return conn, func() { conn.Closed = true }, nil
+		},
+	},
+	{
+		Name: "test_return_shapes_3",
+		Build: func() (*models.ReturnShapesTestConn, func() error, error) {
+			conn := &models.ReturnShapesTestConn{}
+			return conn, func() error {
+				conn.Closed = true
+				return errors.New("close error")
+			}, nil
+		},
+		Unshared: true,
+	},
+	{
+		Name: "test_return_shapes_4",
+		Build: func() (*models.ReturnShapesTestConn, func(), error) {
+			return nil, nil, errors.New("build error")
+		},
+	},
+	{
+		Name: "test_return_shapes_5",
+		Build: func() (*models.ReturnShapesTestConn, *models.ReturnShapesTestError) {
+			return &models.ReturnShapesTestConn{}, nil
+		},
+	},
+	{
+		Name: "test_return_shapes_6",
+		Build: func() (*models.ReturnShapesTestConn, *models.ReturnShapesTestError) {
+			return nil, &models.ReturnShapesTestError{Message: "custom error"}
+		},
+	},
+	{
+		Name: "test_return_shapes_7",
+		Build: func() (fmt.Stringer, func(), error) {
+			s := &models.ReturnShapesTestStringer{}
+			return s, func() { s.Closed = true }, nil
+		},
+		Unshared: true,
+	},
+	{
+		Name: "test_return_shapes_8",
+		Build: func() (fmt.Stringer, func(), error) {
+			return models.ReturnShapesTestName("name"), func() {
+				atomic.AddInt32(&models.ReturnShapesTestNameCleanups, 1)
+			}, nil
+		},
+	},
+}
+
+// InvalidReturnShapesDecls is used in the tests.
+var InvalidReturnShapesDecls = []dingo.Def{
+	{
+		Name: "test_invalid_return_shapes_1",
+		Build: func() (*models.ReturnShapesTestConn, string) {
+			return nil, ""
+		},
+	},
+	{
+		Name: "test_invalid_return_shapes_2",
+		Build: func() (models.ReturnShapesTestValue, func(), error) {
+			return models.ReturnShapesTestValue{}, nil, nil
+		},
+	},
+	{
+		Name: "test_invalid_return_shapes_3",
+		Build: func() (*models.ReturnShapesTestConn, func(), error) {
+			return nil, nil, nil
+		},
+		Close: func(*models.ReturnShapesTestConn) error {
+			return nil
+		},
+	},
+	{
+		Name: "test_invalid_return_shapes_4",
+		Build: func() error {
+			return nil
+		},
+	},
+	{
+		Name: "test_invalid_return_shapes_5",
+		Build: func() (*models.ReturnShapesTestEmpty, func(), error) {
+			return nil, nil, nil
+		},
+	},
+}
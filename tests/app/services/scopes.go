@@ -23,3 +23,26 @@ var ScopeDecls = []dingo.Def{
 		},
 	},
 }
+
+// ScopeMismatchDecls is used in the tests.
+// An app definition depends on a request definition,
+// so they are not included in the main Provider.
+var ScopeMismatchDecls = []dingo.Def{
+	{
+		Name:  "test_scope_mismatch_1",
+		Scope: di.App,
+		Build: func(s *models.ScopeTest) (*models.ScopeTest, error) {
+			return s, nil
+		},
+		Params: dingo.Params{
+			"0": dingo.Service("test_scope_mismatch_2"),
+		},
+	},
+	{
+		Name:  "test_scope_mismatch_2",
+		Scope: di.Request,
+		Build: func() (*models.ScopeTest, error) {
+			return models.NewScopeTest(), nil
+		},
+	},
+}
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/models"
+)
+
+// StructTagsDecls is used in the tests.
+var StructTagsDecls = []dingo.Def{
+	{
+		Name:           "test_struct_tags_logger",
+		Build:          (*models.StructTagsTestLogger)(nil),
+		NotForAutoFill: true,
+	},
+	{
+		Name:  "test_struct_tags_counter",
+		Build: (*models.StructTagsTestCounter)(nil),
+	},
+	{
+		Name:  "test_struct_tags_1",
+		Build: (*models.StructTagsTestService)(nil),
+		Params: dingo.Params{
+			"Overridden": dingo.Service("test_struct_tags_logger"),
+		},
+	},
+	{
+		Name:  "test_struct_tags_2",
+		Build: (*models.StructTagsTestService)(nil),
+		Params: dingo.Params{
+			"Counter":    dingo.AutoFill(false),
+			"NoAutoFill": dingo.AutoFill(true),
+		},
+	},
+}
+
+// InvalidStructTagsDecls is used in the tests.
+var InvalidStructTagsDecls = []dingo.Def{
+	{
+		Name:  "test_invalid_struct_tags_1",
+		Build: (*models.StructTagsTestInvalidService)(nil),
+	},
+}
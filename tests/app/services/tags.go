@@ -0,0 +1,55 @@
+package services
+
+import (
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/models"
+)
+
+// TagsDecls is used in the tests.
+var TagsDecls = []dingo.Def{
+	{
+		Name:           "test_tags_listener_a",
+		Build:          (*models.TagsTestListenerA)(nil),
+		NotForAutoFill: true,
+		Tags: []dingo.Tag{
+			{Name: "test-listener"},
+			{Name: "test-a"},
+		},
+	},
+	{
+		Name:           "test_tags_listener_b",
+		Build:          (*models.TagsTestListenerB)(nil),
+		NotForAutoFill: true,
+		Tags: []dingo.Tag{
+			{Name: "test-listener", Args: map[string]string{"priority": "10"}},
+		},
+	},
+	{
+		Name:  "test_tags_1",
+		Build: (*models.TagsTestBus)(nil),
+		Params: dingo.Params{
+			"Listeners": dingo.Tagged("test-listener"),
+		},
+	},
+}
+
+// InvalidTagsDecls is used in the tests.
+var InvalidTagsDecls = []dingo.Def{
+	{
+		Name:  "test_invalid_tags_1",
+		Build: (*models.TagsTestListenerA)(nil),
+		Tags: []dingo.Tag{
+			{Name: "test-listener", Args: map[string]string{"priority": "high"}},
+		},
+	},
+	{
+		Name:  "test_invalid_tags_2",
+		Build: (*models.TagsTestBus)(nil),
+		Params: dingo.Params{
+			"Listeners": dingo.Tagged("test-bus"),
+		},
+		Tags: []dingo.Tag{
+			{Name: "test-bus"},
+		},
+	},
+}
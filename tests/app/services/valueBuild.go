@@ -0,0 +1,57 @@
+package services
+
+import (
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/models"
+)
+
+// ValueBuildDecls is used in the tests.
+var ValueBuildDecls = []dingo.Def{
+	{
+		Name:  "test_value_build_logger",
+		Build: (*models.ValueBuildTestLogger)(nil),
+	},
+	{
+		Name:  "test_value_build_1",
+		Build: models.ValueBuildTestConfig{},
+		Params: dingo.Params{
+			"Host": "localhost",
+		},
+	},
+	{
+		Name:  "test_value_build_2",
+		Build: models.ValueBuildTestHeaders(nil),
+	},
+	{
+		Name:  "test_value_build_3",
+		Build: models.ValueBuildTestNames(nil),
+	},
+	{
+		Name:  "test_value_build_4",
+		Build: models.ValueBuildTestHandler(nil),
+	},
+	{
+		Name:  "test_value_build_5",
+		Build: models.ValueBuildTestPort(0),
+	},
+}
+
+// InvalidValueBuildDecls is used in the tests.
+var InvalidValueBuildDecls = []dingo.Def{
+	{
+		Name:  "test_invalid_value_build_1",
+		Build: models.ValueBuildTestConfig{Host: "localhost"},
+	},
+	{
+		Name:  "test_invalid_value_build_2",
+		Build: map[string]string(nil),
+	},
+	{
+		Name:  "test_invalid_value_build_3",
+		Build: "",
+	},
+	{
+		Name:  "test_invalid_value_build_4",
+		Build: 0,
+	},
+}
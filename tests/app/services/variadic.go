@@ -0,0 +1,52 @@
+package services
+
+import (
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/models"
+)
+
+// VariadicDecls is used in the tests.
+var VariadicDecls = []dingo.Def{
+	{
+		Name: "test_variadic_option_a",
+		Build: func() (*models.VariadicTestOption, error) {
+			return &models.VariadicTestOption{Name: "a"}, nil
+		},
+	},
+	{
+		Name: "test_variadic_option_b",
+		Build: func() (*models.VariadicTestOption, error) {
+			return &models.VariadicTestOption{Name: "b"}, nil
+		},
+	},
+	{
+		Name:  "test_variadic_1",
+		Build: models.NewVariadicTestServer,
+		Params: dingo.NewFuncParams(
+			"addr",
+			[]*models.VariadicTestOption{{Name: "x"}, {Name: "y"}},
+		),
+	},
+	{
+		Name:  "test_variadic_2",
+		Build: models.NewVariadicTestServer,
+		Params: dingo.NewFuncParams(
+			"addr",
+			[]dingo.Service{"test_variadic_option_b", "test_variadic_option_a"},
+		),
+	},
+	{
+		Name:  "test_variadic_3",
+		Build: models.NewVariadicTestServer,
+		Params: dingo.NewFuncParams(
+			"addr",
+		),
+	},
+	{
+		Name:  "test_variadic_4",
+		Build: models.NewVariadicTestUnusedServer,
+		Params: dingo.NewFuncParams(
+			"addr",
+		),
+	},
+}
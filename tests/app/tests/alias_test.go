@@ -0,0 +1,52 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/generated/dic"
+	"github.com/sarulabs/dingo/v4/tests/app/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlias(t *testing.T) {
+	container, err := dic.NewContainer()
+	require.Nil(t, err)
+
+	service := container.GetTestAliasService()
+	assert.Equal(t, "value", service.Value)
+
+	old, err := container.SafeGet("test_alias_old_service")
+	require.Nil(t, err)
+	assert.Same(t, service, old)
+
+	legacy, err := container.SafeGetTestAliasLegacyService()
+	require.Nil(t, err)
+	assert.Same(t, service, legacy)
+
+	consumer, err := container.SafeGetTestAliasConsumer()
+	require.Nil(t, err)
+	assert.Same(t, service, consumer.Service)
+}
+
+func TestAliasErrors(t *testing.T) {
+	errs := scanErrors(t, services.InvalidAliasDecls)
+	require.Len(t, errs, 4)
+
+	assert.Equal(t, dingo.CodeInvalidName, errs[0].Code)
+	assert.Equal(t, "test_alias_1", errs[0].DefName)
+	assert.Contains(t, errs[0].Error(), "alias test_alias_2 is already used by definition test_alias_2")
+
+	assert.Equal(t, dingo.CodeInvalidName, errs[1].Code)
+	assert.Equal(t, "test_alias_2", errs[1].DefName)
+	assert.Contains(t, errs[1].Error(), "the methods generated for alias test-alias-1 collide with those of definition test_alias_1")
+
+	assert.Equal(t, dingo.CodeInvalidName, errs[2].Code)
+	assert.Equal(t, "test_alias_3", errs[2].DefName)
+	assert.Contains(t, errs[2].Error(), "alias test_alias_old is used more than once")
+
+	assert.Equal(t, dingo.CodeInvalidName, errs[3].Code)
+	assert.Equal(t, "test_alias_4", errs[3].DefName)
+	assert.Contains(t, errs[3].Error(), "a factory can not have aliases")
+}
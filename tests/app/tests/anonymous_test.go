@@ -0,0 +1,29 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/sarulabs/dingo/v4/tests/app/generated/dic"
+	"github.com/sarulabs/dingo/v4/tests/app/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnonymous(t *testing.T) {
+	container, err := dic.NewContainer()
+	require.Nil(t, err)
+
+	res1, err := container.SafeGetTestAnonymous1()
+	require.Nil(t, err)
+	assert.Nil(t, res1.Close())
+	assert.Equal(t, &models.AnonymousTestCloser{Closed: true}, res1)
+
+	res2, err := container.SafeGetTestAnonymous2()
+	require.Nil(t, err)
+	assert.Equal(t, &models.AnonymousTestConfig{Host: "localhost", Port: 8080}, res2)
+
+	res3, err := container.SafeGetTestAnonymous3()
+	require.Nil(t, err)
+	assert.Equal(t, "localhost", res3.Host)
+	assert.Equal(t, "format", res3.Logger.Logf("format", 1, 2))
+}
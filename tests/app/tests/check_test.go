@@ -0,0 +1,68 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/services/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck(t *testing.T) {
+	dir := t.TempDir()
+	pkgDir := filepath.Join(dir, "dic")
+
+	// nothing generated yet
+	diff, err := dingo.CheckContainer((*provider.Provider)(nil), dir, "dic")
+	require.Nil(t, err)
+	require.Len(t, diff.Files, 2)
+	assert.Equal(t, dingo.FileMissing, diff.Files[0].Status)
+	assert.Equal(t, filepath.Join(pkgDir, "container.go"), diff.Files[0].Path)
+	assert.Equal(t, dingo.FileMissing, diff.Files[1].Status)
+	assert.Equal(t, filepath.Join(pkgDir, "defs.go"), diff.Files[1].Path)
+
+	// up to date
+	err = dingo.GenerateContainerWithCustomPkgName((*provider.Provider)(nil), dir, "dic")
+	require.Nil(t, err)
+
+	diff, err = dingo.CheckContainer((*provider.Provider)(nil), dir, "dic")
+	require.Nil(t, err)
+	assert.True(t, diff.IsEmpty())
+
+	// modified and unexpected files
+	content, err := ioutil.ReadFile(filepath.Join(pkgDir, "defs.go"))
+	require.Nil(t, err)
+	require.Nil(t, ioutil.WriteFile(filepath.Join(pkgDir, "defs.go"), append([]byte("// edited\n"), content...), 0664))
+	require.Nil(t, ioutil.WriteFile(filepath.Join(pkgDir, "extra.go"), []byte("package dic\n"), 0664))
+
+	diff, err = dingo.CheckContainer((*provider.Provider)(nil), dir, "dic")
+	require.Nil(t, err)
+	require.Len(t, diff.Files, 2)
+	assert.Equal(t, &dingo.FileDiff{
+		Path:     filepath.Join(pkgDir, "defs.go"),
+		Status:   dingo.FileModified,
+		Line:     1,
+		Expected: strings.SplitN(string(content), "\n", 2)[0],
+		Actual:   "// edited",
+	}, diff.Files[0])
+	assert.Equal(t, &dingo.FileDiff{
+		Path:   filepath.Join(pkgDir, "extra.go"),
+		Status: dingo.FileUnexpected,
+	}, diff.Files[1])
+
+	// generating again fixes everything
+	err = dingo.GenerateContainerWithCustomPkgName((*provider.Provider)(nil), dir, "dic")
+	require.Nil(t, err)
+
+	diff, err = dingo.CheckContainer((*provider.Provider)(nil), dir, "dic")
+	require.Nil(t, err)
+	assert.True(t, diff.IsEmpty())
+
+	_, err = os.Stat(filepath.Join(pkgDir, "extra.go"))
+	assert.True(t, os.IsNotExist(err))
+}
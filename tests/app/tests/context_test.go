@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/generated/dic"
+	"github.com/sarulabs/dingo/v4/tests/app/models"
+	"github.com/sarulabs/dingo/v4/tests/app/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContext(t *testing.T) {
+	container, err := dic.NewContainer()
+	require.Nil(t, err)
+
+	ctx := context.WithValue(context.Background(), models.ContextTestKey("key"), "value")
+
+	res1, err := container.SafeGetTestContext1Ctx(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, "value", res1.Value)
+
+	// The object already exists, so the context is not used.
+	res1 = container.GetTestContext1Ctx(context.Background())
+	assert.Equal(t, "value", res1.Value)
+
+	res2, err := container.SafeGetTestContext2()
+	require.Nil(t, err)
+	assert.Nil(t, res2.Value)
+
+	res2, err = container.SafeGetTestContext2Ctx(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, "value", res2.Value)
+
+	res3, err := container.SafeGetTestContext3Ctx(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, ctx, res3.Ctx)
+}
+
+func TestContextDependencies(t *testing.T) {
+	container, err := dic.NewContainer()
+	require.Nil(t, err)
+
+	ctx := context.WithValue(context.Background(), models.ContextTestKey("key"), "value")
+
+	// The dependencies that are built during the call also receive the context.
+	res4, err := container.SafeGetTestContext4Ctx(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, "value", res4.Value)
+	assert.Equal(t, "value", res4.Client.Value)
+	assert.Same(t, res4.Client, container.GetTestContext5())
+
+	// The context is also passed through the definitions that do not use it.
+	res6, err := container.SafeGetTestContext6Ctx(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, "value", res6.Client.Value)
+	assert.Same(t, res6.Client, container.GetTestContext7())
+
+	// The plain methods use context.Background().
+	container, err = dic.NewContainer()
+	require.Nil(t, err)
+
+	res4, err = container.SafeGetTestContext4()
+	require.Nil(t, err)
+	assert.Nil(t, res4.Value)
+	assert.Nil(t, res4.Client.Value)
+}
+
+func TestContextClose(t *testing.T) {
+	container, err := dic.NewContainer()
+	require.Nil(t, err)
+
+	ctx := context.WithValue(context.Background(), models.ContextTestKey("key"), "value")
+
+	res1, err := container.SafeGetTestContext8Ctx(ctx)
+	require.Nil(t, err)
+	res2, err := container.SafeGetTestContext8()
+	require.Nil(t, err)
+
+	// The unshared objects are closed with the container,
+	// even if they were built with a context.
+	require.Nil(t, container.Delete())
+	assert.True(t, res1.Closed)
+	assert.True(t, res2.Closed)
+}
+
+func TestContextConcurrency(t *testing.T) {
+	shared, err := dic.NewContainer()
+	require.Nil(t, err)
+
+	var wg sync.WaitGroup
+	results := make([]*models.ContextTestClient, 50)
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			ctx := context.WithValue(context.Background(), models.ContextTestKey("key"), i)
+
+			// Each container uses the context given to its own call.
+			container, err := dic.NewContainer()
+			if !assert.Nil(t, err) {
+				return
+			}
+			defer container.Delete()
+
+			if i%2 == 0 {
+				res4, err := container.SafeGetTestContext4Ctx(ctx)
+				if assert.Nil(t, err) {
+					assert.Equal(t, i, res4.Value)
+					assert.Equal(t, i, res4.Client.Value)
+				}
+				res2, err := container.SafeGetTestContext2Ctx(ctx)
+				if assert.Nil(t, err) {
+					assert.Equal(t, i, res2.Value)
+				}
+			} else {
+				res4, err := container.SafeGetTestContext4()
+				if assert.Nil(t, err) {
+					assert.Nil(t, res4.Value)
+					assert.Nil(t, res4.Client.Value)
+				}
+				res2, err := container.SafeGetTestContext2()
+				if assert.Nil(t, err) {
+					assert.Nil(t, res2.Value)
+				}
+			}
+
+			// A shared object is only built once, with the context of one of the calls.
+			res1, err := shared.SafeGetTestContext1Ctx(ctx)
+			if assert.Nil(t, err) {
+				results[i] = res1
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	for _, res := range results {
+		assert.Same(t, results[0], res)
+	}
+}
+
+func TestContextCollision(t *testing.T) {
+	errs := scanErrors(t, services.ContextCollisionDecls)
+	require.Len(t, errs, 1)
+	assert.Equal(t, dingo.CodeInvalidName, errs[0].Code)
+	assert.Equal(t, "test_context_collision", errs[0].DefName)
+}
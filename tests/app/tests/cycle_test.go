@@ -0,0 +1,13 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/sarulabs/dingo/v4/tests/app/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCycle(t *testing.T) {
+	errs := scanErrors(t, services.CycleDecls)
+	assert.Contains(t, errs.Error(), "dependency cycle detected: test_cycle_1 (param B) -> test_cycle_2 (param 0) -> test_cycle_3 (param A) -> test_cycle_1")
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/generated/dic"
+	"github.com/sarulabs/dingo/v4/tests/app/models"
+	"github.com/sarulabs/dingo/v4/tests/app/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecorator(t *testing.T) {
+	container, err := dic.NewContainer()
+	require.Nil(t, err)
+
+	store, err := container.SafeGetTestDecoratorStore()
+	require.Nil(t, err)
+	assert.Equal(t, "metrics(logging(base))", store.Get())
+	assert.Same(t, container.GetTestDecoratorMetrics(), store)
+
+	consumer, err := container.SafeGetTestDecoratorConsumer()
+	require.Nil(t, err)
+	assert.Same(t, store, consumer.Store)
+
+	logging := container.GetTestDecoratorLogging()
+	assert.IsType(t, &models.DecoratorTestBaseStore{}, logging.Inner)
+}
+
+func TestDecoratorErrors(t *testing.T) {
+	errs := scanErrors(t, services.InvalidDecoratorDecls)
+	require.Len(t, errs, 4)
+
+	assert.Equal(t, dingo.CodeInvalidDecorates, errs[0].Code)
+	assert.Equal(t, "test_decorator_invalid_1", errs[0].DefName)
+	assert.Contains(t, errs[0].Error(), "test_decorator_logging is already a decorator")
+
+	assert.Equal(t, dingo.CodeInvalidDecorates, errs[1].Code)
+	assert.Equal(t, "test_decorator_invalid_2", errs[1].DefName)
+	assert.Contains(t, errs[1].Error(), "could not find definition test_decorator_unknown")
+
+	assert.Equal(t, dingo.CodeInvalidDecorates, errs[2].Code)
+	assert.Equal(t, "test_decorator_invalid_3", errs[2].DefName)
+	assert.Contains(t, errs[2].Error(), "should be a models.DecoratorTestStore")
+
+	assert.Equal(t, dingo.CodeInvalidDecorates, errs[3].Code)
+	assert.Equal(t, "test_decorator_invalid_4", errs[3].DefName)
+	assert.Contains(t, errs[3].Error(), "should have a parameter of type models.DecoratorTestStore")
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/generated/dic"
+	"github.com/sarulabs/dingo/v4/tests/app/models"
+	"github.com/sarulabs/dingo/v4/tests/app/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbedded(t *testing.T) {
+	container, err := dic.NewContainer()
+	require.Nil(t, err)
+
+	res1, err := container.SafeGetTestEmbedded1()
+	require.Nil(t, err)
+	assert.Same(t, container.GetTestEmbeddedLogger(), res1.Logger)
+	assert.Equal(t, "base", res1.Name)
+	assert.Equal(t, "inner", res1.Value)
+	assert.Equal(t, 3, res1.Count)
+	assert.Equal(t, "own", res1.Own)
+
+	res2, err := container.SafeGetTestEmbedded2()
+	require.Nil(t, err)
+	assert.Equal(t, models.EmbeddedTestBase{Name: "whole"}, res2.EmbeddedTestBase)
+	assert.Nil(t, res2.EmbeddedTestMiddle)
+}
+
+func TestInvalidEmbedded(t *testing.T) {
+	errs := scanErrors(t, services.InvalidEmbeddedDecls)
+	require.Len(t, errs, 1)
+	assert.Equal(t, dingo.CodeUnknownParam, errs[0].Code)
+	assert.Equal(t, "embeddedTestHidden.Hidden", errs[0].ParamName)
+}
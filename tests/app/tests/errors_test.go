@@ -0,0 +1,64 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type errorsSingleProvider struct {
+	dingo.BaseProvider
+}
+
+func (p *errorsSingleProvider) Load() error {
+	return p.AddDef(services.ErrorsDecls[0])
+}
+
+func TestErrors(t *testing.T) {
+	errs := scanErrors(t, services.ErrorsDecls)
+
+	type entry struct {
+		DefName   string
+		ParamName string
+		Code      dingo.ErrorCode
+	}
+
+	entries := []entry{}
+	for _, e := range errs {
+		entries = append(entries, entry{DefName: e.DefName, ParamName: e.ParamName, Code: e.Code})
+	}
+
+	assert.Equal(t, []entry{
+		{DefName: "test_errors_1", ParamName: "P3", Code: dingo.CodeUnknownParam},
+		{DefName: "test_errors_1", ParamName: "P1", Code: dingo.CodeInvalidParam},
+		{DefName: "test_errors_1", ParamName: "P2", Code: dingo.CodeUnknownService},
+		{DefName: "test_errors_2", Code: dingo.CodeInvalidBuild},
+		{DefName: "test_errors_3", Code: dingo.CodeInvalidClose},
+		{DefName: "test_errors_4", ParamName: "0", Code: dingo.CodeAutoFill},
+	}, entries)
+
+	// The definitions added with a slice only have a location if they use dingo.Declare.
+	assert.Equal(t, "", errs[0].Location)
+	assert.True(t, strings.HasSuffix(errs[3].Location, "services/errors.go:21"), errs[3].Location)
+
+	assert.Contains(t, errs.Error(), "6 errors found in the definitions:\n")
+	assert.Contains(t, errs.Error(), "  - definition test_errors_1, param P2 [unknown_service]: could not find definition undefined for param P2\n")
+	assert.Contains(t, errs.Error(), "  - definition test_errors_2 ("+errs[3].Location+") [invalid_build]: ")
+}
+
+func TestErrorsSingleDefLocation(t *testing.T) {
+	err := dingo.GenerateContainer((*errorsSingleProvider)(nil), t.TempDir())
+	require.NotNil(t, err)
+
+	var errs dingo.DefErrors
+	require.True(t, errors.As(err, &errs))
+	require.NotEmpty(t, errs)
+
+	// A definition added alone gets the place where it is added.
+	assert.True(t, strings.HasSuffix(errs[0].Location, "tests/errors_test.go:19"), errs[0].Location)
+}
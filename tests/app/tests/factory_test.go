@@ -0,0 +1,63 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/generated/dic"
+	"github.com/sarulabs/dingo/v4/tests/app/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFactory(t *testing.T) {
+	container, err := dic.NewContainer()
+	require.Nil(t, err)
+
+	config := container.GetTestFactoryConfig()
+
+	client1, err := container.NewTestFactoryClient("tenant1", 3)
+	require.Nil(t, err)
+	assert.Same(t, config, client1.Config)
+	assert.Equal(t, "tenant1", client1.TenantID)
+	assert.Equal(t, 3, client1.Retries)
+
+	client2, err := container.NewTestFactoryClient("tenant1", 3)
+	require.Nil(t, err)
+	assert.NotSame(t, client1, client2, "the objects created by a factory should not be cached")
+	assert.Same(t, config, client2.Config)
+
+	session, err := container.NewTestFactorySession("user")
+	require.Nil(t, err)
+	assert.Same(t, config, session.Config)
+	assert.Equal(t, "user", session.UserID)
+
+	// Factories are not used for autofill.
+	user, err := container.SafeGetTestFactoryUser()
+	require.Nil(t, err)
+	assert.Nil(t, user.Client)
+}
+
+func TestFactoryErrors(t *testing.T) {
+	errs := scanErrors(t, services.InvalidFactoryDecls)
+	require.Len(t, errs, 5)
+
+	assert.Equal(t, dingo.CodeInvalidClose, errs[0].Code)
+	assert.Equal(t, "test_factory_client", errs[0].DefName)
+
+	assert.Equal(t, dingo.CodeInvalidParam, errs[1].Code)
+	assert.Equal(t, "test_factory_client_2", errs[1].DefName)
+	assert.Contains(t, errs[1].Error(), "argument name err is reserved")
+
+	assert.Equal(t, dingo.CodeInvalidParam, errs[2].Code)
+	assert.Equal(t, "test_factory_client_3", errs[2].DefName)
+	assert.Contains(t, errs[2].Error(), "argument name tenant-id is not a valid identifier")
+
+	assert.Equal(t, dingo.CodeInvalidParam, errs[3].Code)
+	assert.Equal(t, "test_factory_session", errs[3].DefName)
+	assert.Contains(t, errs[3].Error(), "argument value is used more than once")
+
+	assert.Equal(t, dingo.CodeInvalidParam, errs[4].Code)
+	assert.Equal(t, "test_factory_user", errs[4].DefName)
+	assert.Contains(t, errs[4].Error(), "because it is a factory")
+}
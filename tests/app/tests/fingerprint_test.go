@@ -0,0 +1,40 @@
+package main
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/generated/dic"
+	"github.com/sarulabs/dingo/v4/tests/app/services/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFingerprint(t *testing.T) {
+	fingerprints, err := dingo.Fingerprints((*provider.Provider)(nil))
+	require.Nil(t, err)
+
+	// CheckFingerprints uses the definitions of a loaded Provider.
+	p := &provider.Provider{}
+	require.Nil(t, p.Load())
+	assert.Nil(t, dingo.CheckFingerprints(p, fingerprints))
+
+	fingerprints["test_build_func_1"] = "outdated"
+	fingerprints["test_removed"] = "removed"
+	delete(fingerprints, "test_close_1")
+
+	err = dingo.CheckFingerprints(p, fingerprints)
+	require.NotNil(t, err)
+	assert.Equal(t, "the container is out of date, regenerate it (definitions that differ: "+
+		"test_build_func_1 (modified), test_close_1 (added), test_removed (removed))", err.Error())
+}
+
+func TestFingerprintLoadsOnce(t *testing.T) {
+	before := atomic.LoadInt32(&provider.LoadCount)
+
+	// NewBuilder checks the fingerprints with the Provider it has already loaded.
+	_, err := dic.NewBuilder()
+	require.Nil(t, err)
+	assert.Equal(t, before+1, atomic.LoadInt32(&provider.LoadCount))
+}
@@ -0,0 +1,49 @@
+//go:build go1.18
+// +build go1.18
+
+package main
+
+import (
+	"testing"
+
+	"github.com/sarulabs/dingo/v4/tests/app/generated/dic"
+	"github.com/sarulabs/dingo/v4/tests/app/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerics(t *testing.T) {
+	container, err := dic.NewContainer()
+	require.Nil(t, err)
+
+	expected1 := &models.GenericsTestItem{Value: "item"}
+	expected2 := &models.GenericsTestStore[*models.GenericsTestItem]{Item: expected1, Items: []*models.GenericsTestItem{expected1}}
+	expected3 := &models.GenericsTestPair[string, *models.GenericsTestStore[*models.GenericsTestItem]]{Key: "key", Value: expected2}
+	expected4 := &models.GenericsTestInterfacePair{Key: "key", Value: models.InterfacesTestA{Value: "value"}}
+
+	res1, err := container.SafeGetTestGenerics1()
+	assert.Nil(t, err)
+	assert.Equal(t, expected1, res1)
+
+	res2, err := container.SafeGetTestGenerics2()
+	assert.Nil(t, err)
+	assert.Equal(t, expected2, res2)
+
+	res3, err := container.SafeGetTestGenerics3()
+	assert.Nil(t, err)
+	assert.Equal(t, expected3, res3)
+
+	res4, err := container.SafeGetTestGenerics4()
+	assert.Nil(t, err)
+	assert.Equal(t, expected4, res4)
+
+	res5, err := container.SafeGetTestGenerics5()
+	require.Nil(t, err)
+	item, err := res5.Item()
+	assert.Nil(t, err)
+	assert.Same(t, res1, item)
+
+	res6, err := container.SafeGetTestGenerics6()
+	require.Nil(t, err)
+	assert.Equal(t, 2, res6.Value(res1, res1))
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/services/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraph(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	err := dingo.ExportGraph((*provider.Provider)(nil), dingo.GraphJSON, buf)
+	require.Nil(t, err)
+
+	var g dingo.Graph
+	require.Nil(t, json.Unmarshal(buf.Bytes(), &g))
+
+	assert.Contains(t, g.Nodes, &dingo.GraphNode{
+		Name:  "test_close_1",
+		Scope: "app",
+		Type:  "*models.CloseTest",
+		Build: "struct",
+		Close: true,
+	})
+	assert.Contains(t, g.Nodes, &dingo.GraphNode{
+		Name:     "test_unshared_1",
+		Scope:    "app",
+		Type:     "*models.UnsharedTest",
+		Build:    "func",
+		Unshared: true,
+	})
+	assert.Contains(t, g.Edges, &dingo.GraphEdge{
+		From:       "test_autofill_3",
+		To:         "test_autofill_2",
+		Param:      "Value",
+		AutoFilled: true,
+	})
+	for _, edge := range g.Edges {
+		assert.NotEqual(t, "test_build_func_8", edge.From)
+	}
+
+	buf.Reset()
+	err = dingo.ExportGraph((*provider.Provider)(nil), dingo.GraphDOT, buf)
+	require.Nil(t, err)
+	assert.Contains(t, buf.String(), `"test_autofill_3" -> "test_autofill_2" [label="Value", style=dashed];`)
+
+	buf.Reset()
+	err = dingo.ExportGraph((*provider.Provider)(nil), dingo.GraphMermaid, buf)
+	require.Nil(t, err)
+	assert.Contains(t, buf.String(), "flowchart LR\n")
+
+	err = dingo.ExportGraph((*provider.Provider)(nil), dingo.GraphFormat("svg"), buf)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sarulabs/dingo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+// declsProvider is a Provider loaded with the definitions given to scanErrors.
+type declsProvider struct {
+	dingo.BaseProvider
+}
+
+// scanErrors scans definitions that are not part of the main Provider
+// and returns the errors found in them. The test fails if there is none.
+func scanErrors(t *testing.T, decls []dingo.Def) dingo.DefErrors {
+	t.Helper()
+
+	p := &declsProvider{}
+	require.Nil(t, p.AddDefSlice(decls))
+
+	_, err := (&dingo.Scanner{Provider: p}).ScanLoaded()
+	require.NotNil(t, err)
+
+	var errs dingo.DefErrors
+	require.True(t, errors.As(err, &errs), err.Error())
+
+	return errs
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/generated/dic"
+	"github.com/sarulabs/dingo/v4/tests/app/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterfaceAutofill(t *testing.T) {
+	container, err := dic.NewContainer()
+	require.Nil(t, err)
+
+	res1, err := container.SafeGetTestInterfaceAutofill1()
+	require.Nil(t, err)
+	assert.Equal(t, "zap: msg", res1.Logger.Log("msg"))
+	assert.Equal(t, "mail: msg", res1.Notifier.Notify("msg"))
+
+	res2, err := container.SafeGetTestInterfaceAutofill2()
+	require.Nil(t, err)
+	assert.Equal(t, "zap: msg", res2.Logger.Log("msg"))
+
+	res3, err := container.SafeGetTestInterfaceAutofill3()
+	require.Nil(t, err)
+	assert.Nil(t, res3.Store)
+}
+
+func TestAmbiguousInterfaceAutofill(t *testing.T) {
+	errs := scanErrors(t, services.AmbiguousInterfaceAutofillDecls)
+	require.Len(t, errs, 2)
+
+	assert.Equal(t, dingo.CodeAutoFill, errs[0].Code)
+	assert.Equal(t, "test_ambiguous_interface_autofill_1", errs[0].DefName)
+	assert.Equal(t, "0", errs[0].ParamName)
+	assert.Contains(t, errs[0].Error(), "found 2 definitions implementing this interface (test_ambiguous_interface_autofill_logger, test_ambiguous_interface_autofill_mailer)")
+
+	assert.Equal(t, dingo.CodeInvalidAs, errs[1].Code)
+	assert.Equal(t, "test_ambiguous_interface_autofill_2", errs[1].DefName)
+}
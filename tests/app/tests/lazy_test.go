@@ -0,0 +1,77 @@
+package main
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/generated/dic"
+	"github.com/sarulabs/dingo/v4/tests/app/models"
+	"github.com/sarulabs/dingo/v4/tests/app/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLazy(t *testing.T) {
+	container, err := dic.NewContainer()
+	require.Nil(t, err)
+
+	builds := atomic.LoadInt32(&models.LazyTestDBBuilds)
+
+	handler, err := container.SafeGetTestLazyHandler()
+	require.Nil(t, err)
+	require.NotNil(t, handler.GetDB)
+	assert.Equal(t, builds, atomic.LoadInt32(&models.LazyTestDBBuilds), "the db should not be built with the handler")
+
+	db1, err := handler.GetDB()
+	require.Nil(t, err)
+	db2, err := handler.GetDB()
+	require.Nil(t, err)
+	assert.Same(t, db1, db2, "the unshared db should only be retrieved on the first call")
+	assert.Equal(t, builds+1, atomic.LoadInt32(&models.LazyTestDBBuilds))
+
+	report, err := container.SafeGetTestLazyReport()
+	require.Nil(t, err)
+	db3, err := report.GetDB()
+	require.Nil(t, err)
+	assert.NotSame(t, db1, db3)
+}
+
+func TestLazyCycle(t *testing.T) {
+	container, err := dic.NewContainer()
+	require.Nil(t, err)
+
+	parent, err := container.SafeGetTestLazyParent()
+	require.Nil(t, err)
+	require.NotNil(t, parent.Child)
+
+	p, err := parent.Child.GetParent()
+	require.Nil(t, err)
+	assert.Same(t, parent, p)
+}
+
+func TestLazyAmbiguous(t *testing.T) {
+	container, err := dic.NewContainer()
+	require.Nil(t, err)
+
+	user, err := container.SafeGetTestLazyCacheUser()
+	require.Nil(t, err)
+	assert.Nil(t, user.GetCache)
+}
+
+func TestLazyErrors(t *testing.T) {
+	errs := scanErrors(t, services.InvalidLazyDecls)
+	require.Len(t, errs, 3)
+
+	assert.Equal(t, dingo.CodeInvalidParam, errs[0].Code)
+	assert.Equal(t, "test_lazy_child", errs[0].DefName)
+	assert.Contains(t, errs[0].Error(), "should be a *models.LazyTestParent but is a *models.LazyTestDB")
+
+	assert.Equal(t, dingo.CodeInvalidParam, errs[1].Code)
+	assert.Equal(t, "test_lazy_parent_2", errs[1].DefName)
+	assert.Contains(t, errs[1].Error(), "should be a func() (T, error) or a dingo.Lazy[T]")
+
+	assert.Equal(t, dingo.CodeInvalidParam, errs[2].Code)
+	assert.Equal(t, "test_lazy_report", errs[2].DefName)
+	assert.Contains(t, errs[2].Error(), "should be a *models.LazyTestDB but is a *models.LazyTestParent")
+}
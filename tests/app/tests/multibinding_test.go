@@ -0,0 +1,26 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/sarulabs/dingo/v4/tests/app/generated/dic"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiBinding(t *testing.T) {
+	container, err := dic.NewContainer()
+	require.Nil(t, err)
+
+	res1, err := container.SafeGetTestMultibinding1()
+	require.Nil(t, err)
+	assert.Equal(t, "BA", res1.Handle())
+
+	res2, err := container.SafeGetTestMultibinding2()
+	require.Nil(t, err)
+	assert.Equal(t, "BA", res2.Handle())
+
+	res3, err := container.SafeGetTestMultibinding3()
+	require.Nil(t, err)
+	assert.Equal(t, 1, res3)
+}
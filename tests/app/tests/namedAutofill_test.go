@@ -0,0 +1,42 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/generated/dic"
+	"github.com/sarulabs/dingo/v4/tests/app/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamedAutofill(t *testing.T) {
+	container, err := dic.NewContainer()
+	require.Nil(t, err)
+
+	res, err := container.SafeGetTestNamedAutofill1()
+	require.Nil(t, err)
+	assert.Equal(t, "primary", res.TestNamedAutofillPrimaryDB.Name)
+	assert.Equal(t, "replica", res.TestNamedAutofillReplicaDB.Name)
+	assert.Equal(t, "replica", res.Reports.Name)
+}
+
+func TestInvalidNamedAutofill(t *testing.T) {
+	errs := scanErrors(t, services.InvalidNamedAutofillDecls)
+	require.Len(t, errs, 3)
+
+	assert.Equal(t, dingo.CodeAutoFill, errs[0].Code)
+	assert.Equal(t, "test_invalid_named_autofill_1", errs[0].DefName)
+	assert.Equal(t, "DB", errs[0].ParamName)
+	assert.Contains(t, errs[0].Error(), "the dingo tag refers to test_named_autofill_unknown_db")
+
+	assert.Equal(t, dingo.CodeAutoFill, errs[1].Code)
+	assert.Equal(t, "test_invalid_named_autofill_2", errs[1].DefName)
+	assert.Contains(t, errs[1].Error(), "found 2 definitions with this type")
+
+	assert.Equal(t, dingo.CodeAutoFill, errs[2].Code)
+	assert.Equal(t, "test_invalid_named_autofill_3", errs[2].DefName)
+	assert.Equal(t, "Cache", errs[2].ParamName)
+	assert.Contains(t, errs[2].Error(), "the dingo tag refers to test_invalid_named_autofill_cahce, "+
+		"but it is not one of the definitions that could autofill *models.NamedAutofillTestCache (test_invalid_named_autofill_cache)")
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/generated/dic"
+	"github.com/sarulabs/dingo/v4/tests/app/services/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptional(t *testing.T) {
+	container, err := dic.NewContainer()
+	require.Nil(t, err)
+
+	res1, err := container.SafeGetTestOptional1()
+	require.Nil(t, err)
+	assert.Same(t, container.GetTestOptionalCache(), res1.Cache)
+	assert.Nil(t, res1.Metrics)
+
+	res2, err := container.SafeGetTestOptional2()
+	require.Nil(t, err)
+	assert.Same(t, container.GetTestOptionalCache(), res2.Cache)
+	assert.Nil(t, res2.Metrics)
+}
+
+func TestOptionalRemovedService(t *testing.T) {
+	builder, err := dic.NewBuilder()
+	require.Nil(t, err)
+	require.Nil(t, builder.Remove("test_optional_cache"))
+
+	container := builder.Build()
+
+	res1, err := container.SafeGetTestOptional1()
+	require.Nil(t, err)
+	assert.Nil(t, res1.Cache)
+
+	res2, err := container.SafeGetTestOptional2()
+	require.Nil(t, err)
+	assert.Nil(t, res2.Cache)
+}
+
+func TestOptionalComment(t *testing.T) {
+	dir := t.TempDir()
+
+	err := dingo.GenerateContainer((*provider.Provider)(nil), dir)
+	require.Nil(t, err)
+
+	content, err := ioutil.ReadFile(filepath.Join(dir, "dic", "container.go"))
+	require.Nil(t, err)
+	assert.Contains(t, string(content), `"0": Service(*models.OptionalTestCache) ["test_optional_cache"] (optional)`)
+	assert.Contains(t, string(content), `"1": Zero(*models.OptionalTestMetrics) (optional)`)
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/generated/dic"
+	"github.com/sarulabs/dingo/v4/tests/app/services"
+	"github.com/sarulabs/dingo/v4/tests/app/services/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrimary(t *testing.T) {
+	container, err := dic.NewContainer()
+	require.Nil(t, err)
+
+	res, err := container.SafeGetTestPrimary1()
+	require.Nil(t, err)
+	assert.Equal(t, "main", res.DB.Name)
+	assert.Equal(t, "redis", res.Cache.CacheName())
+}
+
+func TestPrimaryComment(t *testing.T) {
+	dir := t.TempDir()
+
+	err := dingo.GenerateContainer((*provider.Provider)(nil), dir)
+	require.Nil(t, err)
+
+	content, err := ioutil.ReadFile(filepath.Join(dir, "dic", "container.go"))
+	require.Nil(t, err)
+	assert.Contains(t, string(content), `"Cache": Service(models.PrimaryTestCache) ["test_primary_cache_redis"] (autofill: primary)`)
+	assert.Contains(t, string(content), `"DB": Service(*models.PrimaryTestDB) ["test_primary_db_main"] (autofill: primary)`)
+}
+
+func TestInvalidPrimary(t *testing.T) {
+	errs := scanErrors(t, services.InvalidPrimaryDecls)
+	require.Len(t, errs, 4)
+
+	assert.Equal(t, dingo.CodeInvalidPrimary, errs[0].Code)
+	assert.Equal(t, "test_invalid_primary_db_2", errs[0].DefName)
+	assert.Contains(t, errs[0].Error(), "definition test_invalid_primary_db_1 is already the primary definition for *models.PrimaryTestDB")
+
+	assert.Equal(t, dingo.CodeInvalidPrimary, errs[1].Code)
+	assert.Equal(t, "test_invalid_primary_db_3", errs[1].DefName)
+
+	assert.Equal(t, dingo.CodeInvalidPrimary, errs[2].Code)
+	assert.Equal(t, "test_invalid_primary_repository", errs[2].DefName)
+	assert.Equal(t, "Cache", errs[2].ParamName)
+	assert.Contains(t, errs[2].Error(), "found 2 (test_invalid_primary_cache_memory, test_invalid_primary_cache_redis)")
+
+	assert.Equal(t, dingo.CodeInvalidPrimary, errs[3].Code)
+	assert.Equal(t, "test_invalid_primary_repository", errs[3].DefName)
+	assert.Equal(t, "DB", errs[3].ParamName)
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/generated/dic"
+	"github.com/sarulabs/dingo/v4/tests/app/models"
+	"github.com/sarulabs/dingo/v4/tests/app/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReturnShapes(t *testing.T) {
+	container, err := dic.NewContainer()
+	require.Nil(t, err)
+
+	res1, err := container.SafeGetTestReturnShapes1()
+	require.Nil(t, err)
+	assert.NotNil(t, res1)
+
+	res2, err := container.SafeGetTestReturnShapes2()
+	require.Nil(t, err)
+
+	res3a, err := container.SafeGetTestReturnShapes3()
+	require.Nil(t, err)
+	res3b, err := container.SafeGetTestReturnShapes3()
+	require.Nil(t, err)
+	assert.NotSame(t, res3a, res3b)
+
+	_, err = container.SafeGetTestReturnShapes4()
+	assert.NotNil(t, err)
+
+	// The error can be any type implementing error.
+	res5, err := container.SafeGetTestReturnShapes5()
+	require.Nil(t, err)
+	assert.NotNil(t, res5)
+
+	_, err = container.SafeGetTestReturnShapes6()
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "custom error")
+
+	assert.False(t, res2.Closed)
+	assert.False(t, res3a.Closed)
+	assert.False(t, res3b.Closed)
+
+	// The cleanup functions are called when the container is deleted.
+	// The errors of the func() error cleanup functions are returned.
+	err = container.Delete()
+	assert.NotNil(t, err)
+	assert.True(t, res2.Closed)
+	assert.True(t, res3a.Closed)
+	assert.True(t, res3b.Closed)
+}
+
+func TestReturnShapesInterface(t *testing.T) {
+	container, err := dic.NewContainer()
+	require.Nil(t, err)
+
+	// Each object returned as an interface has its own cleanup function.
+	res1, err := container.SafeGetTestReturnShapes7()
+	require.Nil(t, err)
+	res2, err := container.SafeGetTestReturnShapes7()
+	require.Nil(t, err)
+	s1 := res1.(*models.ReturnShapesTestStringer)
+	s2 := res2.(*models.ReturnShapesTestStringer)
+	assert.NotSame(t, s1, s2)
+
+	// The cleanup function is called immediately if the value is not a pointer.
+	cleanups := atomic.LoadInt32(&models.ReturnShapesTestNameCleanups)
+	_, err = container.SafeGetTestReturnShapes8()
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "the object is a models.ReturnShapesTestName and not a non-nil pointer")
+	assert.Equal(t, cleanups+1, atomic.LoadInt32(&models.ReturnShapesTestNameCleanups))
+
+	require.Nil(t, container.Delete())
+	assert.True(t, s1.Closed)
+	assert.True(t, s2.Closed)
+}
+
+func TestReturnShapesSeparateContainers(t *testing.T) {
+	container1, err := dic.NewContainer()
+	require.Nil(t, err)
+	container2, err := dic.NewContainer()
+	require.Nil(t, err)
+
+	res1, err := container1.SafeGetTestReturnShapes2()
+	require.Nil(t, err)
+	res2, err := container2.SafeGetTestReturnShapes2()
+	require.Nil(t, err)
+
+	// Each container only calls the cleanup functions of its own objects.
+	require.Nil(t, container1.Delete())
+	assert.True(t, res1.Closed)
+	assert.False(t, res2.Closed)
+
+	require.Nil(t, container2.Delete())
+	assert.True(t, res2.Closed)
+}
+
+func TestInvalidReturnShapes(t *testing.T) {
+	errs := scanErrors(t, services.InvalidReturnShapesDecls)
+	require.Len(t, errs, 5)
+
+	for _, e := range errs {
+		assert.Equal(t, dingo.CodeInvalidBuild, e.Code)
+	}
+
+	assert.Contains(t, errs[0].Error(), "the Build function should return T, (T, error), (T, func(), error) or (T, func() error, error)")
+	assert.Contains(t, errs[1].Error(), "models.ReturnShapesTestValue is not a pointer or an interface")
+	assert.Contains(t, errs[2].Error(), "because the definition has a Close function")
+	assert.Contains(t, errs[3].Error(), "the Build function should return T")
+	assert.Contains(t, errs[4].Error(), "models.ReturnShapesTestEmpty points to a zero-size type")
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/sarulabs/di/v2"
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type customScopesProvider struct {
+	dingo.BaseProvider
+}
+
+func (p *customScopesProvider) Scopes() []string {
+	return []string{di.App, "session"}
+}
+
+func (p *customScopesProvider) Load() error {
+	return p.AddDefSlice(services.ScopeMismatchDecls)
+}
+
+func TestScopeValidation(t *testing.T) {
+	errs := scanErrors(t, services.ScopeMismatchDecls)
+	assert.Contains(t, errs.Error(), "param 0 [scope_mismatch]: the definition scope is app, it can not depend on test_scope_mismatch_2 that belongs to the narrower scope request")
+
+	err := dingo.GenerateContainer((*customScopesProvider)(nil), t.TempDir())
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "[invalid_scope]: scope request is not declared by the Provider (available scopes: app, session)")
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/generated/dic"
+	"github.com/sarulabs/dingo/v4/tests/app/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStructTags(t *testing.T) {
+	container, err := dic.NewContainer()
+	require.Nil(t, err)
+
+	res, err := container.SafeGetTestStructTags1()
+	require.Nil(t, err)
+	assert.Same(t, container.GetTestStructTagsLogger(), res.Logger)
+	assert.Nil(t, res.Missing)
+	assert.Same(t, container.GetTestStructTagsLogger(), res.Overridden)
+	assert.Same(t, container.GetTestStructTagsCounter(), res.Counter)
+	assert.Nil(t, res.Skipped)
+	assert.Nil(t, res.NoAutoFill)
+
+	// Params have priority over the tags.
+	res, err = container.SafeGetTestStructTags2()
+	require.Nil(t, err)
+	assert.Nil(t, res.Counter)
+	assert.Same(t, container.GetTestStructTagsCounter(), res.NoAutoFill)
+}
+
+func TestInvalidStructTags(t *testing.T) {
+	errs := scanErrors(t, services.InvalidStructTagsDecls)
+	require.Len(t, errs, 2)
+
+	assert.Equal(t, dingo.CodeInvalidParam, errs[0].Code)
+	assert.Equal(t, "Counter", errs[0].ParamName)
+	assert.Contains(t, errs[0].Error(), "the dingo tag option lazy=true is not supported")
+
+	assert.Equal(t, dingo.CodeUnknownService, errs[1].Code)
+	assert.Equal(t, "Logger", errs[1].ParamName)
+}
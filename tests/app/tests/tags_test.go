@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/generated/dic"
+	"github.com/sarulabs/dingo/v4/tests/app/models"
+	"github.com/sarulabs/dingo/v4/tests/app/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTags(t *testing.T) {
+	container, err := dic.NewContainer()
+	require.Nil(t, err)
+
+	bus, err := container.SafeGetTestTags1()
+	require.Nil(t, err)
+	require.Len(t, bus.Listeners, 2)
+	assert.Equal(t, "B", bus.Listeners[0].Listen())
+	assert.Equal(t, "A", bus.Listeners[1].Listen())
+
+	listeners, err := container.SafeGetAllTaggedTestListener()
+	require.Nil(t, err)
+	require.Len(t, listeners, 2)
+	assert.Equal(t, &models.TagsTestListenerB{}, listeners[0])
+	assert.Equal(t, &models.TagsTestListenerA{}, listeners[1])
+
+	// The tag only contains one type, so the slice is typed.
+	var a []*models.TagsTestListenerA = container.GetAllTaggedTestA()
+	assert.Len(t, a, 1)
+}
+
+func TestInvalidTags(t *testing.T) {
+	errs := scanErrors(t, services.InvalidTagsDecls)
+	require.Len(t, errs, 2)
+	assert.Equal(t, dingo.CodeInvalidTag, errs[0].Code)
+	assert.Equal(t, "test_invalid_tags_1", errs[0].DefName)
+	assert.Equal(t, dingo.CodeInvalidParam, errs[1].Code)
+	assert.Equal(t, "test_invalid_tags_2", errs[1].DefName)
+	assert.Contains(t, errs[1].Error(), "can not contain test_invalid_tags_2 tagged with test-bus")
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/sarulabs/dingo/v4"
+	"github.com/sarulabs/dingo/v4/tests/app/generated/dic"
+	"github.com/sarulabs/dingo/v4/tests/app/models"
+	"github.com/sarulabs/dingo/v4/tests/app/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValueBuild(t *testing.T) {
+	container, err := dic.NewContainer()
+	require.Nil(t, err)
+
+	var res1 models.ValueBuildTestConfig
+	res1, err = container.SafeGetTestValueBuild1()
+	require.Nil(t, err)
+	assert.Equal(t, "localhost", res1.Host)
+	assert.Same(t, container.GetTestValueBuildLogger(), res1.Logger)
+
+	var res2 models.ValueBuildTestHeaders
+	res2, err = container.SafeGetTestValueBuild2()
+	require.Nil(t, err)
+	assert.Nil(t, res2)
+
+	var res3 models.ValueBuildTestNames
+	res3, err = container.SafeGetTestValueBuild3()
+	require.Nil(t, err)
+	assert.Nil(t, res3)
+
+	var res4 models.ValueBuildTestHandler
+	res4, err = container.SafeGetTestValueBuild4()
+	require.Nil(t, err)
+	assert.Nil(t, res4)
+
+	var res5 models.ValueBuildTestPort
+	res5, err = container.SafeGetTestValueBuild5()
+	require.Nil(t, err)
+	assert.Equal(t, models.ValueBuildTestPort(0), res5)
+}
+
+func TestInvalidValueBuild(t *testing.T) {
+	errs := scanErrors(t, services.InvalidValueBuildDecls)
+	require.Len(t, errs, 4)
+
+	assert.Equal(t, dingo.CodeInvalidBuild, errs[0].Code)
+	assert.Contains(t, errs[0].Error(), "the definition Build property should be a zero value")
+
+	assert.Equal(t, dingo.CodeInvalidBuild, errs[1].Code)
+	assert.Equal(t, "test_invalid_value_build_2", errs[1].DefName)
+
+	// The zero values of predeclared types are not accepted.
+	for _, e := range errs[2:] {
+		assert.Equal(t, dingo.CodeInvalidBuild, e.Code)
+		assert.Contains(t, e.Error(), "the definition Build property should be a function, a pointer to a structure")
+	}
+	assert.Equal(t, "test_invalid_value_build_3", errs[2].DefName)
+	assert.Equal(t, "test_invalid_value_build_4", errs[3].DefName)
+}
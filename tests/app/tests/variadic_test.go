@@ -0,0 +1,32 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/sarulabs/dingo/v4/tests/app/generated/dic"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVariadic(t *testing.T) {
+	container, err := dic.NewContainer()
+	require.Nil(t, err)
+
+	res1, err := container.SafeGetTestVariadic1()
+	require.Nil(t, err)
+	assert.Equal(t, "addr", res1.Addr)
+	assert.Equal(t, []string{"x", "y"}, res1.Options)
+
+	res2, err := container.SafeGetTestVariadic2()
+	require.Nil(t, err)
+	assert.Equal(t, []string{"b", "a"}, res2.Options)
+
+	res3, err := container.SafeGetTestVariadic3()
+	require.Nil(t, err)
+	assert.Equal(t, []string{"a", "b"}, res3.Options)
+
+	res4, err := container.SafeGetTestVariadic4()
+	require.Nil(t, err)
+	assert.Equal(t, "addr", res4.Addr)
+	assert.Empty(t, res4.Options)
+}
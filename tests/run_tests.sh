@@ -7,8 +7,11 @@ testsDir="$( cd "$( dirname "${BASH_SOURCE[0]}" )" >/dev/null 2>&1 && pwd )"
 echo ">>> GENERATING CODE ..."
 go run "${testsDir}/app/main.go" "${testsDir}/app/generated"
 
+echo ">>> CHECKING GENERATED CODE ..."
+go run "${testsDir}/app/main.go" -check "${testsDir}/app/generated"
+
 echo ">>> RUNNING TESTS ..."
-go test -v "${testsDir}/app/tests"
+go test -v -race "${testsDir}/app/tests"
 
 echo ">>> REMOVING GENERATED CODE from ${testsDir}/app/generated ..."
 rm -rf "${testsDir}/app/generated"
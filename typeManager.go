@@ -21,8 +21,15 @@ var reservedPkgNames = map[string]struct{}{
 // that are used in the types that it has registered.
 // It associates a unique alias to all the import paths.
 type TypeManager struct {
-	imports map[string]string
-	aliases map[string]int
+	imports  map[string]string
+	aliases  map[string]int
+	generics bool
+}
+
+// UsesGenerics returns true if an instantiated generic type has been registered.
+// In this case the generated code requires go1.18 or later.
+func (tm *TypeManager) UsesGenerics() bool {
+	return tm.generics
 }
 
 // Imports returns a map with all the imports that are used
@@ -121,10 +128,99 @@ func (tm *TypeManager) registerBasicType(t reflect.Type) (string, error) {
 }
 
 func (tm *TypeManager) registerNamedType(t reflect.Type) (string, error) {
+	name, err := tm.registerTypeArgs(t.Name())
+	if err != nil {
+		return "", err
+	}
 	if alias := tm.addImport(t.PkgPath()); alias != "" {
-		return alias + "." + t.Name(), nil
+		return alias + "." + name, nil
+	}
+	return name, nil
+}
+
+// registerTypeArgs handles the names of instantiated generic types.
+// For these types, reflect returns names like "Store[github.com/user/pkg.Item]",
+// with the full import path of the type arguments.
+// The import paths are replaced by their aliases, and the packages are imported.
+func (tm *TypeManager) registerTypeArgs(name string) (string, error) {
+	start := strings.IndexByte(name, '[')
+	if start < 0 {
+		return name, nil
 	}
-	return t.Name(), nil
+	if !strings.HasSuffix(name, "]") {
+		return "", errors.New("could not parse the type arguments of " + name)
+	}
+	args, err := tm.registerTypeString(name[start+1 : len(name)-1])
+	if err != nil {
+		return "", err
+	}
+	tm.generics = true
+	return name[:start] + "[" + args + "]", nil
+}
+
+// registerTypeString takes a type as it is written by reflect (e.g. "map[string]*github.com/user/pkg.Item")
+// and replaces the package paths of the qualified identifiers by their aliases.
+func (tm *TypeManager) registerTypeString(s string) (string, error) {
+	buf := &strings.Builder{}
+
+	for i := 0; i < len(s); {
+		// Quoted strings (struct tags) are copied without modification.
+		if s[i] == '"' {
+			end := i + 1
+			for end < len(s) && s[end] != '"' {
+				if s[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(s) {
+				return "", errors.New("could not parse type " + s)
+			}
+			buf.WriteString(s[i : end+1])
+			i = end + 1
+			continue
+		}
+
+		if !isTypeStringTokenChar(s[i]) {
+			buf.WriteByte(s[i])
+			i++
+			continue
+		}
+
+		end := i
+		for end < len(s) && isTypeStringTokenChar(s[end]) {
+			end++
+		}
+
+		buf.WriteString(tm.registerQualifiedIdentifier(s[i:end]))
+		i = end
+	}
+
+	return buf.String(), nil
+}
+
+// registerQualifiedIdentifier converts "github.com/user/pkg.Item" into "pkg.Item"
+// and adds the import. Tokens that are not qualified identifiers are returned as is.
+// The variadic marker of a parameter ("...github.com/user/pkg.Item") is kept.
+func (tm *TypeManager) registerQualifiedIdentifier(token string) string {
+	if strings.HasPrefix(token, "...") {
+		return "..." + tm.registerQualifiedIdentifier(token[3:])
+	}
+	dot := strings.LastIndexByte(token, '.')
+	if dot <= 0 || dot == len(token)-1 {
+		return token
+	}
+	if alias := tm.addImport(token[:dot]); alias != "" {
+		return alias + "." + token[dot+1:]
+	}
+	return token[dot+1:]
+}
+
+// isTypeStringTokenChar returns true if the character
+// can be part of an identifier or an import path.
+func isTypeStringTokenChar(c byte) bool {
+	return c == '_' || c == '.' || c == '/' || c == '-' || c == '~' || c >= 0x80 ||
+		('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
 }
 
 func (tm *TypeManager) registerChan(t reflect.Type) (string, error) {
@@ -142,10 +238,27 @@ func (tm *TypeManager) registerFunc(t reflect.Type) (string, error) {
 	if t.Name() != "" {
 		return tm.registerNamedType(t)
 	}
+	signature, err := tm.registerSignature(t)
+	if err != nil {
+		return "", err
+	}
+	return "func" + signature, nil
+}
 
+// registerSignature returns the signature of a function type,
+// without the func keyword (e.g. "(string, ...int) (bool, error)").
+func (tm *TypeManager) registerSignature(t reflect.Type) (string, error) {
 	inTypes := make([]string, 0, t.NumIn())
 
 	for i := 0; i < t.NumIn(); i++ {
+		if t.IsVariadic() && i == t.NumIn()-1 {
+			eltType, err := tm.Register(t.In(i).Elem())
+			if err != nil {
+				return "", err
+			}
+			inTypes = append(inTypes, "..."+eltType)
+			continue
+		}
 		eltType, err := tm.Register(t.In(i))
 		if err != nil {
 			return "", err
@@ -165,11 +278,11 @@ func (tm *TypeManager) registerFunc(t reflect.Type) (string, error) {
 
 	switch len(outTypes) {
 	case 0:
-		return "func(" + strings.Join(inTypes, ", ") + ")", nil
+		return "(" + strings.Join(inTypes, ", ") + ")", nil
 	case 1:
-		return "func(" + strings.Join(inTypes, ", ") + ") " + outTypes[0], nil
+		return "(" + strings.Join(inTypes, ", ") + ") " + outTypes[0], nil
 	default:
-		return "func(" + strings.Join(inTypes, ", ") + ") (" + strings.Join(outTypes, ", ") + ")", nil
+		return "(" + strings.Join(inTypes, ", ") + ") (" + strings.Join(outTypes, ", ") + ")", nil
 	}
 }
 
@@ -177,7 +290,26 @@ func (tm *TypeManager) registerInterface(t reflect.Type) (string, error) {
 	if t.Name() != "" {
 		return tm.registerNamedType(t)
 	}
-	return "interface{}", nil
+
+	methods := make([]string, 0, t.NumMethod())
+
+	for i := 0; i < t.NumMethod(); i++ {
+		m := t.Method(i)
+		if m.PkgPath != "" {
+			return "", errors.New("interface with the unexported method " + m.Name + " is not supported")
+		}
+		signature, err := tm.registerSignature(m.Type)
+		if err != nil {
+			return "", err
+		}
+		methods = append(methods, m.Name+signature)
+	}
+
+	if len(methods) == 0 {
+		return "interface{}", nil
+	}
+
+	return "interface { " + strings.Join(methods, "; ") + " }", nil
 }
 
 func (tm *TypeManager) registerMap(t reflect.Type) (string, error) {
@@ -221,7 +353,42 @@ func (tm *TypeManager) registerStruct(t reflect.Type) (string, error) {
 	if t.Name() != "" {
 		return tm.registerNamedType(t)
 	}
-	return "struct{}", nil
+
+	fields := make([]string, 0, t.NumField())
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			return "", errors.New("struct with the unexported field " + f.Name + " is not supported")
+		}
+
+		field, err := tm.Register(f.Type)
+		if err != nil {
+			return "", err
+		}
+		if !f.Anonymous {
+			field = f.Name + " " + field
+		}
+		if f.Tag != "" {
+			field += " " + formatTag(string(f.Tag))
+		}
+
+		fields = append(fields, field)
+	}
+
+	if len(fields) == 0 {
+		return "struct{}", nil
+	}
+
+	return "struct { " + strings.Join(fields, "; ") + " }", nil
+}
+
+// formatTag returns the struct tag as a string literal.
+func formatTag(tag string) string {
+	if strings.Contains(tag, "`") {
+		return strconv.Quote(tag)
+	}
+	return "`" + tag + "`"
 }
 
 func (tm *TypeManager) addImport(pkg string) string {